@@ -0,0 +1,414 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+/*
+Client for the RabbitMQ management interface. rabbit-hole does not accept
+a context, so the transport is kept around to bind the requests of
+each operation to its context.
+*/
+type rabbitmqClient struct {
+	*rabbithole.Client
+
+	transport http.RoundTripper
+	timeout   time.Duration
+}
+
+// Returns a copy of the client whose requests are cancelled along with ctx.
+func (c *rabbitmqClient) WithContext(ctx context.Context) *rabbithole.Client {
+
+	rmqc := *c.Client
+
+	rmqc.SetTransport(&contextTransport{ctx: ctx, transport: c.transport})
+
+	return &rmqc
+}
+
+/*
+Sends a JSON request to the management interface for the endpoints
+rabbit-hole has no suitable method for, e.g. because its types drop
+fields. The response body is decoded into out unless it is nil.
+*/
+func (c *rabbitmqClient) executeJSONRequest(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+
+	var body io.Reader
+
+	if in != nil {
+
+		raw, err := json.Marshal(in)
+
+		if err != nil {
+
+			return err
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.Endpoint, "/")+"/api/"+path, body)
+
+	if err != nil {
+
+		return err
+	}
+
+	req.SetBasicAuth(c.Username, c.Password)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Transport: c.transport, Timeout: c.timeout}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+
+		errorResponse := rabbithole.ErrorResponse{StatusCode: resp.StatusCode}
+
+		json.NewDecoder(resp.Body).Decode(&errorResponse)
+
+		return errorResponse
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	// The request context already carries the client timeout,
+	// so it is cancelled as well when the operation context is done.
+	ctx, cancel := context.WithCancel(req.Context())
+	stop := context.AfterFunc(t.ctx, cancel)
+
+	release := func() {
+
+		stop()
+		cancel()
+	}
+
+	resp, err := t.transport.RoundTrip(req.WithContext(ctx))
+
+	if err != nil {
+
+		release()
+
+		return nil, err
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// Releases the request context once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+
+	err := b.ReadCloser.Close()
+
+	b.release()
+
+	return err
+}
+
+/*
+Retries idempotent requests failing with a connection error or a status
+returned while the management interface is starting, overloaded or
+restarting, waiting with exponential backoff in between.
+*/
+type retryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	for attempt := 0; ; attempt++ {
+
+		resp, err := t.transport.RoundTrip(req)
+
+		if attempt >= t.maxRetries || !isIdempotent(req) || !isRetryable(req, resp, err) {
+
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		if err != nil {
+
+			log.Printf("[WARN] RabbitMQ: %s %s failed, retrying in %s (%d/%d): %s", req.Method, req.URL.Path, wait, attempt+1, t.maxRetries, err)
+
+		} else {
+
+			log.Printf("[WARN] RabbitMQ: %s %s returned %s, retrying in %s (%d/%d)", req.Method, req.URL.Path, resp.Status, wait, attempt+1, t.maxRetries)
+
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+
+		case <-time.After(wait):
+		}
+
+		if req.GetBody != nil {
+
+			body, err := req.GetBody()
+
+			if err != nil {
+
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// Returns how long to wait before the next attempt, honouring Retry-After.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+
+	wait := t.minWait
+
+	// doubling stops at the maximum so that the wait cannot overflow
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+
+		wait *= 2
+	}
+
+	if resp != nil {
+
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+
+			wait = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if wait > t.maxWait {
+
+		wait = t.maxWait
+	}
+
+	if wait < 0 {
+
+		wait = 0
+	}
+
+	return wait
+}
+
+func isIdempotent(req *http.Request) bool {
+
+	switch req.Method {
+
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+
+	if err != nil {
+
+		// cancelled or timed out operations are not retried
+		return req.Context().Err() == nil
+	}
+
+	switch resp.StatusCode {
+
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+/*
+Sends requests to one of the management interfaces of a cluster, failing
+over to the next endpoint when a request fails with a connection error.
+Requests are built by rabbit-hole against the first endpoint and
+rewritten to the selected one.
+*/
+type failoverTransport struct {
+	transport  http.RoundTripper
+	endpoints  []*url.URL
+	roundRobin bool
+
+	// index of the endpoint to use next
+	current uint32
+}
+
+func newFailoverTransport(transport http.RoundTripper, endpoints []string, roundRobin bool) (*failoverTransport, error) {
+
+	t := &failoverTransport{transport: transport, roundRobin: roundRobin}
+
+	for _, endpoint := range endpoints {
+
+		u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+
+		if err != nil {
+
+			return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+
+		t.endpoints = append(t.endpoints, u)
+	}
+
+	return t, nil
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	var start uint32
+
+	if t.roundRobin {
+
+		start = atomic.AddUint32(&t.current, 1) - 1
+
+	} else {
+
+		start = atomic.LoadUint32(&t.current)
+	}
+
+	var err error
+
+	for i := range t.endpoints {
+
+		index := (int(start) + i) % len(t.endpoints)
+
+		r, rewriteErr := t.rewrite(req, t.endpoints[index], i > 0)
+
+		if rewriteErr != nil {
+
+			return nil, rewriteErr
+		}
+
+		var resp *http.Response
+
+		resp, err = t.transport.RoundTrip(r)
+
+		if err == nil {
+
+			if !t.roundRobin {
+
+				atomic.StoreUint32(&t.current, uint32(index))
+			}
+
+			return resp, nil
+		}
+
+		if req.Context().Err() != nil {
+
+			return nil, err
+		}
+
+		log.Printf("[WARN] RabbitMQ: endpoint %s is unavailable, failing over: %s", t.endpoints[index].Host, err)
+	}
+
+	return nil, err
+}
+
+// Points the request built against the first endpoint to the given one.
+func (t *failoverTransport) rewrite(req *http.Request, endpoint *url.URL, resend bool) (*http.Request, error) {
+
+	r := req.Clone(req.Context())
+
+	path := strings.TrimPrefix(req.URL.EscapedPath(), t.endpoints[0].EscapedPath())
+
+	u, err := url.Parse(endpoint.String() + path)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	u.RawQuery = req.URL.RawQuery
+
+	r.URL = u
+	r.Host = ""
+
+	if resend && req.GetBody != nil {
+
+		if r.Body, err = req.GetBody(); err != nil {
+
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+/*
+Selects the first endpoint whose management interface responds at all.
+Authentication is not checked, any response below 500 counts as healthy.
+*/
+func (t *failoverTransport) selectHealthy(timeout time.Duration) {
+
+	client := &http.Client{Transport: t.transport, Timeout: timeout}
+
+	for index, endpoint := range t.endpoints {
+
+		resp, err := client.Get(endpoint.String() + "/api/overview")
+
+		if err == nil {
+
+			resp.Body.Close()
+
+			if resp.StatusCode < http.StatusInternalServerError {
+
+				atomic.StoreUint32(&t.current, uint32(index))
+
+				return
+			}
+
+			err = fmt.Errorf("%s", resp.Status)
+		}
+
+		log.Printf("[WARN] RabbitMQ: endpoint %s failed the health check: %s", endpoint.Host, err)
+	}
+
+	log.Printf("[WARN] RabbitMQ: no endpoint passed the health check, starting with %s", t.endpoints[0].Host)
+}
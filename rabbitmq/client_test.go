@@ -0,0 +1,234 @@
+package rabbitmq
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_withContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := providerConfig{
+		Endpoint: server.URL,
+		Username: "guest",
+		Password: "guest",
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		_, err := client.WithContext(ctx).GetVhost("test")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected the request to be cancelled")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled along with its context")
+	}
+}
+
+func TestClient_retry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "test"}`))
+	}))
+	defer server.Close()
+
+	config := providerConfig{
+		Endpoint:   server.URL,
+		Username:   "guest",
+		Password:   "guest",
+		MaxRetries: 3,
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	vhost, err := client.WithContext(context.Background()).GetVhost("test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if vhost.Name != "test" || attempts != 3 {
+		t.Fatalf("expected vhost test after 3 attempts, got %q after %d", vhost.Name, attempts)
+	}
+
+	attempts = 0
+	config.MaxRetries = 1
+
+	client, err = config.Client()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := client.WithContext(context.Background()).GetVhost("test"); err == nil {
+		t.Fatal("expected an error once the retries are exhausted")
+	}
+}
+
+func TestRetryTransport_backoff(t *testing.T) {
+	for name, test := range map[string]struct {
+		minWait    time.Duration
+		maxWait    time.Duration
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		"first attempt":       {minWait: time.Second, maxWait: 30 * time.Second, attempt: 0, expected: time.Second},
+		"exponential":         {minWait: time.Second, maxWait: 30 * time.Second, attempt: 3, expected: 8 * time.Second},
+		"maximum":             {minWait: time.Second, maxWait: 30 * time.Second, attempt: 10, expected: 30 * time.Second},
+		"no overflow":         {minWait: time.Second, maxWait: 30 * time.Second, attempt: 100, expected: 30 * time.Second},
+		"immediate retries":   {minWait: 0, maxWait: 30 * time.Second, attempt: 3, expected: 0},
+		"retry after":         {minWait: time.Second, maxWait: 30 * time.Second, attempt: 0, retryAfter: "5", expected: 5 * time.Second},
+		"retry after zero":    {minWait: time.Second, maxWait: 30 * time.Second, attempt: 2, retryAfter: "0", expected: 0},
+		"retry after maximum": {minWait: time.Second, maxWait: 30 * time.Second, attempt: 0, retryAfter: "120", expected: 30 * time.Second},
+	} {
+		transport := &retryTransport{minWait: test.minWait, maxWait: test.maxWait}
+
+		resp := &http.Response{Header: http.Header{}}
+		if test.retryAfter != "" {
+			resp.Header.Set("Retry-After", test.retryAfter)
+		}
+
+		if wait := transport.backoff(test.attempt, resp); wait != test.expected {
+			t.Errorf("%s: expected a wait of %s, got %s", name, test.expected, wait)
+		}
+	}
+}
+
+func TestClient_failover(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "/"}`))
+	}))
+	defer up.Close()
+
+	for _, selection := range []string{"first_healthy", "round_robin"} {
+		config := providerConfig{
+			Endpoints:         []string{down.URL, up.URL},
+			EndpointSelection: selection,
+			Username:          "guest",
+			Password:          "guest",
+		}
+
+		client, err := config.Client()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		for i := 0; i < 3; i++ {
+			vhost, err := client.WithContext(context.Background()).GetVhost("/")
+			if err != nil {
+				t.Fatalf("%s: err: %s", selection, err)
+			}
+
+			if vhost.Name != "/" {
+				t.Fatalf("%s: expected vhost /, got %q", selection, vhost.Name)
+			}
+		}
+	}
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	for dialTimeout, expected := range map[int]time.Duration{
+		0:  maxHealthCheckTimeout * time.Second,
+		5:  5 * time.Second,
+		30: maxHealthCheckTimeout * time.Second,
+	} {
+		if timeout := healthCheckTimeout(dialTimeout); timeout != expected {
+			t.Errorf("%d: expected a timeout of %s, got %s", dialTimeout, expected, timeout)
+		}
+	}
+}
+
+func TestClient_oauth2(t *testing.T) {
+	tokens := 0
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "client_credentials" {
+			t.Errorf("unexpected grant type %q", r.FormValue("grant_type"))
+		}
+		tokens++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "issued", "token_type": "bearer", "expires_in": 3600}`))
+	}))
+	defer tokenServer.Close()
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "test"}`))
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		oauth2   *oauth2Config
+		expected string
+	}{
+		{&oauth2Config{Token: "static"}, "Bearer static"},
+		{&oauth2Config{ClientID: "terraform", ClientSecret: "secret", TokenEndpoint: tokenServer.URL, Scopes: []string{"rabbitmq.tag:administrator"}}, "Bearer issued"},
+	} {
+		config := providerConfig{
+			Endpoint: server.URL,
+			OAuth2:   tc.oauth2,
+		}
+
+		client, err := config.Client()
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.WithContext(context.Background()).GetVhost("test"); err != nil {
+				t.Fatalf("err: %s", err)
+			}
+
+			if authorization != tc.expected {
+				t.Fatalf("expected Authorization %q, got %q", tc.expected, authorization)
+			}
+		}
+	}
+
+	if tokens != 1 {
+		t.Fatalf("expected the issued token to be reused, got %d token requests", tokens)
+	}
+
+	config := providerConfig{
+		Endpoint: server.URL,
+		OAuth2:   &oauth2Config{ClientID: "terraform"},
+	}
+
+	if _, err := config.Client(); err == nil {
+		t.Fatal("expected an error for incomplete client credentials")
+	}
+}
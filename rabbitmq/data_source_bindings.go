@@ -0,0 +1,171 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBindings() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceBindingsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"vhost": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"source": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"destination": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"destination_type": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"queue",
+					"exchange",
+				}, false),
+			},
+
+			"bindings": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+
+					Schema: map[string]*schema.Schema{
+
+						"vhost": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"source": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"destination": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"destination_type": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"routing_key": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"properties_key": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"arguments_json": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBindingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	var bindings []rabbithole.BindingInfo
+	var err error
+
+	if vhost := d.Get("vhost").(string); vhost != "" {
+
+		bindings, err = rmqc.ListBindingsIn(vhost)
+
+	} else {
+
+		bindings, err = rmqc.ListBindings()
+	}
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot list bindings: %s", err))
+	}
+
+	source := optionalStringFilter(d, "source")
+	destination := d.Get("destination").(string)
+	destinationType := d.Get("destination_type").(string)
+
+	results := make([]map[string]interface{}, 0, len(bindings))
+
+	for _, binding := range bindings {
+
+		// the source of bindings to the default exchange is empty
+		if source != nil && binding.Source != *source {
+
+			continue
+		}
+
+		if destination != "" && binding.Destination != destination {
+
+			continue
+		}
+
+		if destinationType != "" && binding.DestinationType != destinationType {
+
+			continue
+		}
+
+		results = append(results, map[string]interface{}{
+
+			"vhost":            binding.Vhost,
+			"source":           binding.Source,
+			"destination":      binding.Destination,
+			"destination_type": binding.DestinationType,
+			"routing_key":      binding.RoutingKey,
+			"properties_key":   binding.PropertiesKey,
+			"arguments_json":   toString(binding.Arguments),
+		})
+	}
+
+	d.SetId(listDataSourceID(d, "vhost", "source", "destination", "destination_type"))
+
+	if err := d.Set("bindings", results); err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceBindingsConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+
+  name = "test"
+}
+
+resource "rabbitmq_permissions" "test" {
+
+  vhost = rabbitmq_vhost.test.id
+  user  = "guest"
+
+  permissions {
+
+    configure = ".*"
+    write     = ".*"
+    read      = ".*"
+  }
+}
+
+resource "rabbitmq_exchange" "test" {
+
+  vhost = rabbitmq_permissions.test.vhost
+  name  = "orders"
+
+  settings {
+
+    type = "topic"
+  }
+}
+
+resource "rabbitmq_queue" "test" {
+
+  vhost = rabbitmq_permissions.test.vhost
+  name  = "orders"
+
+  settings {
+
+    durable = true
+  }
+}
+
+resource "rabbitmq_binding" "test" {
+
+  vhost            = rabbitmq_vhost.test.id
+  source           = rabbitmq_exchange.test.name
+  destination      = rabbitmq_queue.test.name
+  destination_type = "queue"
+  routing_key      = "orders.#"
+}
+
+data "rabbitmq_bindings" "test" {
+
+  vhost  = rabbitmq_vhost.test.id
+  source = "orders"
+
+  depends_on = [rabbitmq_binding.test]
+}`
+
+func TestAccDataSourceBindings_basic(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBindingsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.0.destination", "orders"),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.0.routing_key", "orders.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceBindingsConfig_defaultExchange = `
+resource "rabbitmq_vhost" "test" {
+
+  name = "test"
+}
+
+resource "rabbitmq_permissions" "test" {
+
+  vhost = rabbitmq_vhost.test.id
+  user  = "guest"
+
+  permissions {
+
+    configure = ".*"
+    write     = ".*"
+    read      = ".*"
+  }
+}
+
+resource "rabbitmq_queue" "test" {
+
+  vhost = rabbitmq_permissions.test.vhost
+  name  = "orders"
+
+  settings {
+
+    durable = true
+  }
+}
+
+data "rabbitmq_bindings" "test" {
+
+  vhost       = rabbitmq_vhost.test.id
+  source      = ""
+  destination = rabbitmq_queue.test.name
+}`
+
+func TestAccDataSourceBindings_defaultExchange(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBindingsConfig_defaultExchange,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.0.source", ""),
+					resource.TestCheckResourceAttr("data.rabbitmq_bindings.test", "bindings.0.routing_key", "orders"),
+				),
+			},
+		},
+	})
+}
@@ -1,18 +1,18 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
 func dataSourceExchange() *schema.Resource {
 
 	return &schema.Resource{
 
-		Read: dataSourceExchangeRead,
+		ReadContext: dataSourceExchangeRead,
 
 		Schema: map[string]*schema.Schema{
 
@@ -27,36 +27,80 @@ func dataSourceExchange() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"type": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"durable": {
+
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"auto_delete": {
+
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"internal": {
+
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"arguments": {
+
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"arguments_json": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func dataSourceExchangeRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceExchangeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	vhost, _, _, err := parseIdWithArgs(d.Get("vhost").(string))
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	name, _, _, err := parseIdWithArgs(d.Get("name").(string))
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	exchange, err := rmqc.GetExchange(vhost, name)
 
 	if err != nil {
 
-		return checkDeleted(d, fmt.Errorf("cannot locate exchange: %s", err))
+		return diag.FromErr(checkDeleted(d, fmt.Errorf("cannot locate exchange: %s", err)))
 	}
 
 	d.SetId(fmt.Sprintf("%s@%s@%s", exchange.Name, exchange.Vhost, fmt.Sprintf("%t:%t:%s", exchange.Durable, exchange.AutoDelete, toString(exchange.Arguments))))
 
+	d.Set("type", exchange.Type)
+	d.Set("durable", exchange.Durable)
+	d.Set("auto_delete", bool(exchange.AutoDelete))
+	d.Set("internal", exchange.Internal)
+	d.Set("arguments", flattenArguments(exchange.Arguments))
+	d.Set("arguments_json", toString(exchange.Arguments))
+
 	return nil
 }
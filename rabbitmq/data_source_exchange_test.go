@@ -59,7 +59,14 @@ func TestAccDataSourceExchange_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceExchangeConfig_basic,
-				Check:  resource.TestMatchResourceAttr("data.rabbitmq_exchange.test", "id", regexp.MustCompile("test@test@false:true:{}")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.rabbitmq_exchange.test", "id", regexp.MustCompile("test@test@false:true:{}")),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchange.test", "type", "fanout"),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchange.test", "durable", "false"),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchange.test", "auto_delete", "true"),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchange.test", "internal", "false"),
+					resource.TestCheckResourceAttr("data.rabbitmq_exchange.test", "arguments_json", "{}"),
+				),
 			},
 		},
 	})
@@ -0,0 +1,184 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceExchanges() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceExchangesRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"vhost": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name_regex": {
+
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"type": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"durable": {
+
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"names": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"exchanges": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+
+					Schema: map[string]*schema.Schema{
+
+						"name": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vhost": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"durable": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"auto_delete": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"internal": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"arguments_json": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceExchangesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	nameRegex, err := nameRegexFilter(d)
+
+	if err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	var exchanges []rabbithole.ExchangeInfo
+
+	if vhost := d.Get("vhost").(string); vhost != "" {
+
+		exchanges, err = rmqc.ListExchangesIn(vhost)
+
+	} else {
+
+		exchanges, err = rmqc.ListExchanges()
+	}
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot list exchanges: %s", err))
+	}
+
+	exchangeType := d.Get("type").(string)
+	durable := optionalBoolFilter(d, "durable")
+
+	names := make([]string, 0, len(exchanges))
+	results := make([]map[string]interface{}, 0, len(exchanges))
+
+	for _, exchange := range exchanges {
+
+		if nameRegex != nil && !nameRegex.MatchString(exchange.Name) {
+
+			continue
+		}
+
+		if exchangeType != "" && exchange.Type != exchangeType {
+
+			continue
+		}
+
+		if durable != nil && exchange.Durable != *durable {
+
+			continue
+		}
+
+		names = append(names, exchange.Name)
+
+		results = append(results, map[string]interface{}{
+
+			"name":           exchange.Name,
+			"vhost":          exchange.Vhost,
+			"type":           exchange.Type,
+			"durable":        exchange.Durable,
+			"auto_delete":    bool(exchange.AutoDelete),
+			"internal":       exchange.Internal,
+			"arguments_json": toString(exchange.Arguments),
+		})
+	}
+
+	d.SetId(listDataSourceID(d, "vhost", "name_regex", "type", "durable"))
+
+	d.Set("names", names)
+
+	if err := d.Set("exchanges", results); err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceExchangesConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+
+  name = "test"
+}
+
+resource "rabbitmq_permissions" "test" {
+
+  vhost = rabbitmq_vhost.test.id
+  user  = "guest"
+
+  permissions {
+
+    configure = ".*"
+    write     = ".*"
+    read      = ".*"
+  }
+}
+
+resource "rabbitmq_exchange" "test" {
+
+  vhost = rabbitmq_permissions.test.vhost
+  name  = "orders"
+
+  settings {
+
+    type        = "topic"
+    durable     = true
+    auto_delete = false
+  }
+}
+
+data "rabbitmq_exchanges" "test" {
+
+  vhost = rabbitmq_vhost.test.id
+  type  = "topic"
+
+  depends_on = [rabbitmq_exchange.test]
+}`
+
+func TestAccDataSourceExchanges_basic(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceExchangesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					// amq.topic is declared along with the vhost
+					resource.TestCheckResourceAttr("data.rabbitmq_exchanges.test", "names.#", "2"),
+					resource.TestCheckTypeSetElemAttr("data.rabbitmq_exchanges.test", "names.*", "orders"),
+				),
+			},
+		},
+	})
+}
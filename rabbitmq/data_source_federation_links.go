@@ -0,0 +1,166 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceFederationLinks() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceFederationLinksRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"vhost": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"upstream": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"type": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"exchange",
+					"queue",
+				}, false),
+			},
+
+			"links": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+
+					Schema: map[string]*schema.Schema{
+
+						"id": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vhost": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"upstream": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"node": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"timestamp": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"local_name": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"upstream_name": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"local_connection": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"error": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFederationLinksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	links, err := listFederationLinks(ctx, meta.(*rabbitmqClient), d.Get("vhost").(string), d.Get("upstream").(string))
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot list federation links: %s", err))
+	}
+
+	linkType := d.Get("type").(string)
+
+	results := make([]map[string]interface{}, 0, len(links))
+
+	for _, link := range links {
+
+		if linkType != "" && link.Type != linkType {
+
+			continue
+		}
+
+		results = append(results, map[string]interface{}{
+
+			"id":               link.Id,
+			"vhost":            link.Vhost,
+			"upstream":         link.Upstream,
+			"type":             link.Type,
+			"status":           link.Status,
+			"node":             link.Node,
+			"timestamp":        link.Timestamp,
+			"local_name":       link.localName(),
+			"upstream_name":    link.upstreamName(),
+			"local_connection": link.LocalConnection,
+			"error":            link.Error,
+		})
+	}
+
+	d.SetId(listDataSourceID(d, "vhost", "upstream", "type"))
+
+	if err := d.Set("links", results); err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
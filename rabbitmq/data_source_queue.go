@@ -1,18 +1,22 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/url"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceQueue() *schema.Resource {
 
 	return &schema.Resource{
 
-		Read: dataSourceQueueRead,
+		ReadContext: dataSourceQueueRead,
 
 		Schema: map[string]*schema.Schema{
 
@@ -27,36 +31,135 @@ func dataSourceQueue() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"type": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"durable": {
+
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"auto_delete": {
+
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"exclusive": {
+
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"arguments": {
+
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"arguments_json": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"messages": {
+
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"consumers": {
+
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"node": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"policy": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"effective_policy_definition": {
+
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"effective_policy_definition_json": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func dataSourceQueueRead(d *schema.ResourceData, meta interface{}) error {
-
-	rmqc := meta.(*rabbithole.Client)
+func dataSourceQueueRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	vhost, _, _, err := parseIdWithArgs(d.Get("vhost").(string))
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	name, _, _, err := parseIdWithArgs(d.Get("name").(string))
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
-	queue, err := rmqc.GetQueue(vhost, name)
+	// rabbit-hole leaves out the effective policy definition
+	var queue struct {
+		rabbithole.QueueInfo
 
-	if err != nil {
+		EffectivePolicyDefinition map[string]interface{} `json:"effective_policy_definition"`
+	}
 
-		return checkDeleted(d, fmt.Errorf("cannot locate queue: %s", err))
+	path := fmt.Sprintf("queues/%s/%s", url.PathEscape(vhost), url.PathEscape(name))
+
+	if err := meta.(*rabbitmqClient).executeJSONRequest(ctx, http.MethodGet, path, nil, &queue); err != nil {
+
+		return diag.FromErr(checkDeleted(d, fmt.Errorf("cannot locate queue: %s", err)))
 	}
 
 	d.SetId(fmt.Sprintf("%s@%s@%s", queue.Name, queue.Vhost, fmt.Sprintf("%t:%t:%s", queue.Durable, queue.AutoDelete, toString(queue.Arguments))))
 
+	d.Set("type", queueTypeOf(queue.QueueInfo))
+	d.Set("durable", queue.Durable)
+	d.Set("auto_delete", bool(queue.AutoDelete))
+	d.Set("exclusive", queue.Exclusive)
+	d.Set("arguments", flattenArguments(queue.Arguments))
+	d.Set("arguments_json", toString(queue.Arguments))
+	d.Set("messages", queue.Messages)
+	d.Set("consumers", queue.Consumers)
+	d.Set("node", queue.Node)
+	d.Set("state", queue.Status)
+	d.Set("policy", queue.Policy)
+	d.Set("effective_policy_definition", flattenArguments(queue.EffectivePolicyDefinition))
+	d.Set("effective_policy_definition_json", toString(queue.EffectivePolicyDefinition))
+
 	return nil
 }
@@ -58,7 +58,15 @@ func TestAccDataSourceQueue_basic(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceQueueConfig_basic,
-				Check:  resource.TestMatchResourceAttr("data.rabbitmq_queue.test", "id", regexp.MustCompile("test@test@false:true:{}")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.rabbitmq_queue.test", "id", regexp.MustCompile("test@test@false:true:{}")),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "type", "classic"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "durable", "false"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "auto_delete", "true"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "arguments_json", "{}"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queue.test", "messages", "0"),
+					resource.TestCheckResourceAttrSet("data.rabbitmq_queue.test", "node"),
+				),
 			},
 		},
 	})
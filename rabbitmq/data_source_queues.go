@@ -0,0 +1,243 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceQueues() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceQueuesRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"vhost": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name_regex": {
+
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"type": {
+
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"classic",
+					"quorum",
+					"stream",
+				}, false),
+			},
+
+			"durable": {
+
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"names": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"queues": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+
+					Schema: map[string]*schema.Schema{
+
+						"name": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vhost": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"type": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"durable": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"auto_delete": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"exclusive": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"arguments_json": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"node": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"state": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"policy": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"messages": {
+
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"consumers": {
+
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceQueuesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	nameRegex, err := nameRegexFilter(d)
+
+	if err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	var queues []rabbithole.QueueInfo
+
+	if vhost := d.Get("vhost").(string); vhost != "" {
+
+		queues, err = rmqc.ListQueuesIn(vhost)
+
+	} else {
+
+		queues, err = rmqc.ListQueues()
+	}
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot list queues: %s", err))
+	}
+
+	queueType := d.Get("type").(string)
+	durable := optionalBoolFilter(d, "durable")
+
+	names := make([]string, 0, len(queues))
+	results := make([]map[string]interface{}, 0, len(queues))
+
+	for _, queue := range queues {
+
+		if nameRegex != nil && !nameRegex.MatchString(queue.Name) {
+
+			continue
+		}
+
+		if queueType != "" && queueTypeOf(queue) != queueType {
+
+			continue
+		}
+
+		if durable != nil && queue.Durable != *durable {
+
+			continue
+		}
+
+		names = append(names, queue.Name)
+
+		results = append(results, map[string]interface{}{
+
+			"name":           queue.Name,
+			"vhost":          queue.Vhost,
+			"type":           queueTypeOf(queue),
+			"durable":        queue.Durable,
+			"auto_delete":    bool(queue.AutoDelete),
+			"exclusive":      queue.Exclusive,
+			"arguments_json": toString(queue.Arguments),
+			"node":           queue.Node,
+			"state":          queue.Status,
+			"policy":         queue.Policy,
+			"messages":       queue.Messages,
+			"consumers":      queue.Consumers,
+		})
+	}
+
+	d.SetId(listDataSourceID(d, "vhost", "name_regex", "type", "durable"))
+
+	d.Set("names", names)
+
+	if err := d.Set("queues", results); err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+/*
+Returns the type of the queue. Servers older than 3.8 do not report
+it, in which case it is taken from the x-queue-type argument.
+*/
+func queueTypeOf(queue rabbithole.QueueInfo) string {
+
+	if queue.Type != "" {
+
+		return queue.Type
+	}
+
+	if queueType, ok := queue.Arguments["x-queue-type"].(string); ok {
+
+		return queueType
+	}
+
+	return "classic"
+}
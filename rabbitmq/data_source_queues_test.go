@@ -0,0 +1,92 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceQueuesConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+
+  name = "test"
+}
+
+resource "rabbitmq_permissions" "test" {
+
+  vhost = rabbitmq_vhost.test.id
+  user  = "guest"
+
+  permissions {
+
+    configure = ".*"
+    write     = ".*"
+    read      = ".*"
+  }
+}
+
+resource "rabbitmq_queue" "durable" {
+
+  vhost = rabbitmq_permissions.test.vhost
+  name  = "orders"
+
+  settings {
+
+    durable     = true
+    auto_delete = false
+  }
+}
+
+resource "rabbitmq_queue" "transient" {
+
+  vhost = rabbitmq_permissions.test.vhost
+  name  = "orders-transient"
+
+  settings {
+
+    durable     = false
+    auto_delete = true
+  }
+}
+
+data "rabbitmq_queues" "all" {
+
+  vhost      = rabbitmq_vhost.test.id
+  name_regex = "^orders"
+
+  depends_on = [rabbitmq_queue.durable, rabbitmq_queue.transient]
+}
+
+data "rabbitmq_queues" "transient" {
+
+  vhost   = rabbitmq_vhost.test.id
+  durable = false
+
+  depends_on = [rabbitmq_queue.durable, rabbitmq_queue.transient]
+}`
+
+func TestAccDataSourceQueues_basic(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceQueuesConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.all", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.transient", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.transient", "queues.0.name", "orders-transient"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.transient", "queues.0.type", "classic"),
+					resource.TestCheckResourceAttr("data.rabbitmq_queues.transient", "queues.0.auto_delete", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,160 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceShovelStatus() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceShovelStatusRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name": {
+
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"vhost": {
+
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"node": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"timestamp": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_error": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// may contain credentials
+			"source_uri": {
+
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"source_protocol": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_queue": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_exchange": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"destination_uri": {
+
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"destination_protocol": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"destination_queue": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"destination_exchange": {
+
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceShovelStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	vhost, _, _, err := parseIdWithArgs(d.Get("vhost").(string))
+
+	if err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	name, _, _, err := parseIdWithArgs(d.Get("name").(string))
+
+	if err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	status, err := getShovelStatus(ctx, meta.(*rabbitmqClient), vhost, name)
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot read shovel status: %s", err))
+	}
+
+	if status == nil {
+
+		return diag.Errorf("cannot locate shovel status: %s@%s", name, vhost)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", status.Name, status.Vhost))
+
+	d.Set("type", status.Type)
+	d.Set("state", status.State)
+	d.Set("node", status.Node)
+	d.Set("timestamp", status.Timestamp)
+	d.Set("last_error", status.Reason)
+	d.Set("source_uri", status.SourceURI)
+	d.Set("source_protocol", status.SourceProtocol)
+	d.Set("source_queue", status.SourceQueue)
+	d.Set("source_exchange", status.SourceExchange)
+	d.Set("destination_uri", status.DestinationURI)
+	d.Set("destination_protocol", status.DestinationProtocol)
+	d.Set("destination_queue", status.DestinationQueue)
+	d.Set("destination_exchange", status.DestinationExchange)
+
+	return nil
+}
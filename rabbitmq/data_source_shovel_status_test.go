@@ -0,0 +1,44 @@
+package rabbitmq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceShovelStatusConfig_basic = `
+
+data "rabbitmq_shovel_status" "test" {
+
+  vhost = rabbitmq_shovel.shovelTest.vhost
+  name  = rabbitmq_shovel.shovelTest.name
+}`
+
+func TestAccDataSourceShovelStatus_basic(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(testAccShovelConfig_basic, "\tinfo {", "\twait_for_running = true\n\tinfo {", 1) + testAccDataSourceShovelStatusConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_shovel_status.test", "id", "shovelTest@test"),
+					resource.TestCheckResourceAttr("data.rabbitmq_shovel_status.test", "type", "dynamic"),
+					resource.TestCheckResourceAttr("data.rabbitmq_shovel_status.test", "state", "running"),
+					resource.TestCheckResourceAttr("data.rabbitmq_shovel_status.test", "source_exchange", "test_exchange"),
+					resource.TestCheckResourceAttr("data.rabbitmq_shovel_status.test", "destination_queue", "test_queue"),
+					resource.TestCheckResourceAttr("data.rabbitmq_shovel_status.test", "last_error", ""),
+					resource.TestCheckResourceAttrSet("data.rabbitmq_shovel_status.test", "node"),
+				),
+			},
+		},
+	})
+}
@@ -1,18 +1,18 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
 func dataSourceUser() *schema.Resource {
 
 	return &schema.Resource{
 
-		Read: dataSourceUserRead,
+		ReadContext: dataSourceUserRead,
 
 		Schema: map[string]*schema.Schema{
 
@@ -25,15 +25,15 @@ func dataSourceUser() *schema.Resource {
 	}
 }
 
-func dataSourceUserRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, err := rmqc.GetUser(d.Get("name").(string))
 
 	if err != nil {
 
-		return checkDeleted(d, fmt.Errorf("cannot locate user: %s", err))
+		return diag.FromErr(checkDeleted(d, fmt.Errorf("cannot locate user: %s", err)))
 	}
 
 	d.SetId(user.Name)
@@ -0,0 +1,129 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceUsers() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceUsersRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name_regex": {
+
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"tags": {
+
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"names": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"users": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+
+					Schema: map[string]*schema.Schema{
+
+						"name": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tags": {
+
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"hashing_algorithm": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	nameRegex, err := nameRegexFilter(d)
+
+	if err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	users, err := rmqc.ListUsers()
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot list users: %s", err))
+	}
+
+	tags := d.Get("tags").(*schema.Set)
+
+	names := make([]string, 0, len(users))
+	results := make([]map[string]interface{}, 0, len(users))
+
+	for _, user := range users {
+
+		if nameRegex != nil && !nameRegex.MatchString(user.Name) {
+
+			continue
+		}
+
+		if !hasAllTags(user.Tags, tags) {
+
+			continue
+		}
+
+		names = append(names, user.Name)
+
+		results = append(results, map[string]interface{}{
+
+			"name":              user.Name,
+			"tags":              []string(user.Tags),
+			"hashing_algorithm": string(user.HashingAlgorithm),
+		})
+	}
+
+	d.SetId(listDataSourceID(d, "name_regex", "tags"))
+
+	d.Set("names", names)
+
+	if err := d.Set("users", results); err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceUsersConfig_basic = `
+resource "rabbitmq_user" "test" {
+
+  name     = "monitoring-test"
+  password = "foobar"
+  tags     = ["monitoring"]
+}
+
+data "rabbitmq_users" "test" {
+
+  name_regex = "-test$"
+  tags       = ["monitoring"]
+
+  depends_on = [rabbitmq_user.test]
+}`
+
+func TestAccDataSourceUsers_basic(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsersConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_users.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_users.test", "users.0.name", "monitoring-test"),
+					resource.TestCheckResourceAttr("data.rabbitmq_users.test", "users.0.tags.0", "monitoring"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the regular expression names must match, or nil when not filtering by name.
+func nameRegexFilter(d *schema.ResourceData) (*regexp.Regexp, error) {
+
+	expression := d.Get("name_regex").(string)
+
+	if expression == "" {
+
+		return nil, nil
+	}
+
+	return regexp.Compile(expression)
+}
+
+/*
+Returns the configured value of an optional bool filter, or nil when
+it is not set. A false value cannot be told apart from an unset one
+through Get, so the raw configuration is checked instead.
+*/
+func optionalBoolFilter(d *schema.ResourceData, key string) *bool {
+
+	value := d.GetRawConfig().GetAttr(key)
+
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.Bool {
+
+		return nil
+	}
+
+	result := value.True()
+
+	return &result
+}
+
+/*
+Returns the configured value of an optional string filter, or nil when
+it is not set. An empty value cannot be told apart from an unset one
+through GetOk, so the raw configuration is checked instead.
+*/
+func optionalStringFilter(d *schema.ResourceData, key string) *string {
+
+	value := d.GetRawConfig().GetAttr(key)
+
+	if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+
+		return nil
+	}
+
+	result := value.AsString()
+
+	return &result
+}
+
+// Returns whether the tags contain every wanted tag.
+func hasAllTags(tags []string, wanted *schema.Set) bool {
+
+	present := make(map[string]bool)
+
+	for _, tag := range tags {
+
+		present[tag] = true
+	}
+
+	for _, tag := range wanted.List() {
+
+		if !present[tag.(string)] {
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns an identifier for a list data source built from its filters.
+func listDataSourceID(d *schema.ResourceData, keys ...string) string {
+
+	var parts []string
+
+	for _, key := range keys {
+
+		value, ok := d.GetOk(key)
+
+		if !ok {
+
+			continue
+		}
+
+		if set, ok := value.(*schema.Set); ok {
+
+			var tags []string
+
+			for _, tag := range set.List() {
+
+				tags = append(tags, tag.(string))
+			}
+
+			sort.Strings(tags)
+
+			value = strings.Join(tags, "+")
+		}
+
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	}
+
+	if len(parts) == 0 {
+
+		return "*"
+	}
+
+	return strings.Join(parts, ",")
+}
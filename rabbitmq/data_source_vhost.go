@@ -1,18 +1,18 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
 func dataSourceVhost() *schema.Resource {
 
 	return &schema.Resource{
 
-		Read: dataSourceVhostRead,
+		ReadContext: dataSourceVhostRead,
 
 		Schema: map[string]*schema.Schema{
 
@@ -25,22 +25,22 @@ func dataSourceVhost() *schema.Resource {
 	}
 }
 
-func dataSourceVhostRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceVhostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name, _, _, err := parseIdWithArgs(d.Get("name").(string))
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	vhost, err := rmqc.GetVhost(name)
 
 	if err != nil {
 
-		return checkDeleted(d, fmt.Errorf("cannot locate vhost: %s", err))
+		return diag.FromErr(checkDeleted(d, fmt.Errorf("cannot locate vhost: %s", err)))
 	}
 
 	d.SetId(vhost.Name)
@@ -0,0 +1,136 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceVhosts() *schema.Resource {
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceVhostsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"name_regex": {
+
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+
+			"tags": {
+
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"names": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"vhosts": {
+
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+
+					Schema: map[string]*schema.Schema{
+
+						"name": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"description": {
+
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tags": {
+
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"tracing": {
+
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVhostsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	nameRegex, err := nameRegexFilter(d)
+
+	if err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	vhosts, err := rmqc.ListVhosts()
+
+	if err != nil {
+
+		return diag.FromErr(fmt.Errorf("cannot list vhosts: %s", err))
+	}
+
+	tags := d.Get("tags").(*schema.Set)
+
+	names := make([]string, 0, len(vhosts))
+	results := make([]map[string]interface{}, 0, len(vhosts))
+
+	for _, vhost := range vhosts {
+
+		if nameRegex != nil && !nameRegex.MatchString(vhost.Name) {
+
+			continue
+		}
+
+		if !hasAllTags(vhost.Tags, tags) {
+
+			continue
+		}
+
+		names = append(names, vhost.Name)
+
+		results = append(results, map[string]interface{}{
+
+			"name":        vhost.Name,
+			"description": vhost.Description,
+			"tags":        []string(vhost.Tags),
+			"tracing":     vhost.Tracing,
+		})
+	}
+
+	d.SetId(listDataSourceID(d, "name_regex", "tags"))
+
+	d.Set("names", names)
+
+	if err := d.Set("vhosts", results); err != nil {
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceVhostsConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+
+  name = "vhosts-test"
+}
+
+data "rabbitmq_vhosts" "test" {
+
+  name_regex = "^vhosts-"
+
+  depends_on = [rabbitmq_vhost.test]
+}`
+
+func TestAccDataSourceVhosts_basic(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+
+		PreCheck: func() {
+
+			testAccPreCheck(t)
+		},
+
+		Providers: testAccProviders,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceVhostsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rabbitmq_vhosts.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.rabbitmq_vhosts.test", "vhosts.0.name", "vhosts-test"),
+				),
+			},
+		},
+	})
+}
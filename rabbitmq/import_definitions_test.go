@@ -0,0 +1,31 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDefinitions_importBasic(t *testing.T) {
+	resourceName := "rabbitmq_definitions.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDefinitionsCheckDestroy("test", "orders", "invoices"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefinitionsConfig_basic,
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "definitions@test",
+				ImportStateVerify: true,
+				// the document is adopted by the next apply
+				ImportStateVerifyIgnore: []string{"definitions"},
+			},
+		},
+	})
+}
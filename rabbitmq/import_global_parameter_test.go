@@ -0,0 +1,31 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGlobalParameter_importBasic(t *testing.T) {
+	resourceName := "rabbitmq_global_parameter.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGlobalParameterCheckDestroy("terraform-test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalParameterConfig_basic,
+				Check: testAccGlobalParameterCheck(
+					resourceName, `{"environment": "test", "replicas": 3}`,
+				),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
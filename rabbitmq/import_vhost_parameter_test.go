@@ -0,0 +1,31 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVhostParameter_importBasic(t *testing.T) {
+	resourceName := "rabbitmq_vhost_parameter.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVhostParameterCheckDestroy("federation-upstream-set", "test", "upstreams"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVhostParameterConfig_basic,
+				Check: testAccVhostParameterCheck(
+					resourceName, `[{"upstream": "a"}]`,
+				),
+			},
+
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
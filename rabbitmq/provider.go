@@ -1,25 +1,32 @@
 package rabbitmq
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"endpoint": {
-				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_ENDPOINT", nil),
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"endpoints"},
+				DefaultFunc:   schema.EnvDefaultFunc("RABBITMQ_ENDPOINT", nil),
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
 					value := v.(string)
 					if value == "" {
@@ -30,9 +37,28 @@ func Provider() *schema.Provider {
 				},
 			},
 
+			"endpoints": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"endpoint"},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"endpoint_selection": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"first_healthy",
+					"round_robin",
+				}, false),
+			},
+
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_USERNAME", nil),
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
 					value := v.(string)
@@ -46,7 +72,8 @@ func Provider() *schema.Provider {
 
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_PASSWORD", nil),
 				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
 					value := v.(string)
@@ -58,6 +85,43 @@ func Provider() *schema.Provider {
 				},
 			},
 
+			"oauth2": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+
+						"client_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"client_secret": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+
+						"token_endpoint": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"scopes": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -87,21 +151,61 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("RABBITMQ_PROXY", ""),
 			},
+
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("RABBITMQ_REQUEST_TIMEOUT", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"dial_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("RABBITMQ_DIAL_TIMEOUT", defaultDialTimeout),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("RABBITMQ_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_min_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("RABBITMQ_RETRY_MIN_WAIT", defaultRetryMinWait),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("RABBITMQ_RETRY_MAX_WAIT", defaultRetryMaxWait),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"rabbitmq_binding":             resourceBinding(),
-			"rabbitmq_exchange":            resourceExchange(),
-			"rabbitmq_permissions":         resourcePermissions(),
-			"rabbitmq_topic_permissions":   resourceTopicPermissions(),
-			"rabbitmq_federation_upstream": resourceFederationUpstream(),
-			"rabbitmq_operator_policy":     resourceOperatorPolicy(),
-			"rabbitmq_policy":              resourcePolicy(),
-			"rabbitmq_queue":               resourceQueue(),
-			"rabbitmq_user":                resourceUser(),
-			"rabbitmq_vhost":               resourceVhost(),
-			"rabbitmq_shovel":              resourceShovel(),
-			"rabbitmq_limit":               resourceLimit(),
+			"rabbitmq_binding":                 resourceBinding(),
+			"rabbitmq_exchange":                resourceExchange(),
+			"rabbitmq_permissions":             resourcePermissions(),
+			"rabbitmq_topic_permissions":       resourceTopicPermissions(),
+			"rabbitmq_federation_upstream":     resourceFederationUpstream(),
+			"rabbitmq_federation_upstream_set": resourceFederationUpstreamSet(),
+			"rabbitmq_operator_policy":         resourceOperatorPolicy(),
+			"rabbitmq_policy":                  resourcePolicy(),
+			"rabbitmq_queue":                   resourceQueue(),
+			"rabbitmq_user":                    resourceUser(),
+			"rabbitmq_vhost":                   resourceVhost(),
+			"rabbitmq_shovel":                  resourceShovel(),
+			"rabbitmq_limit":                   resourceLimit(),
+			"rabbitmq_definitions":             resourceDefinitions(),
+			"rabbitmq_global_parameter":        resourceGlobalParameter(),
+			"rabbitmq_vhost_parameter":         resourceVhostParameter(),
+			"rabbitmq_quorum_queue_membership": resourceQuorumQueueMembership(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -110,6 +214,15 @@ func Provider() *schema.Provider {
 			"rabbitmq_user":     dataSourceUser(),
 			"rabbitmq_queue":    dataSourceQueue(),
 			"rabbitmq_exchange": dataSourceExchange(),
+
+			"rabbitmq_vhosts":    dataSourceVhosts(),
+			"rabbitmq_users":     dataSourceUsers(),
+			"rabbitmq_queues":    dataSourceQueues(),
+			"rabbitmq_exchanges": dataSourceExchanges(),
+			"rabbitmq_bindings":  dataSourceBindings(),
+
+			"rabbitmq_shovel_status":    dataSourceShovelStatus(),
+			"rabbitmq_federation_links": dataSourceFederationLinks(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -118,22 +231,174 @@ func Provider() *schema.Provider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 
-	var username = d.Get("username").(string)
-	var password = d.Get("password").(string)
-	var endpoint = d.Get("endpoint").(string)
-	var insecure = d.Get("insecure").(bool)
-	var cacertFile = d.Get("cacert_file").(string)
-	var clientcertFile = d.Get("clientcert_file").(string)
-	var clientkeyFile = d.Get("clientkey_file").(string)
-	var proxy = d.Get("proxy").(string)
+	var endpoints []string
+	for _, v := range d.Get("endpoints").([]interface{}) {
+		if endpoint, ok := v.(string); ok {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	var oauth2Settings *oauth2Config
+	if v, ok := d.Get("oauth2").([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		oauth2Map := v[0].(map[string]interface{})
+
+		oauth2Settings = &oauth2Config{
+			Token:         oauth2Map["token"].(string),
+			ClientID:      oauth2Map["client_id"].(string),
+			ClientSecret:  oauth2Map["client_secret"].(string),
+			TokenEndpoint: oauth2Map["token_endpoint"].(string),
+		}
+
+		for _, scope := range oauth2Map["scopes"].([]interface{}) {
+			if scope, ok := scope.(string); ok {
+				oauth2Settings.Scopes = append(oauth2Settings.Scopes, scope)
+			}
+		}
+	}
+
+	config := providerConfig{
+		Endpoint:          d.Get("endpoint").(string),
+		Endpoints:         endpoints,
+		EndpointSelection: d.Get("endpoint_selection").(string),
+		Username:          d.Get("username").(string),
+		Password:          d.Get("password").(string),
+		OAuth2:            oauth2Settings,
+		Insecure:          d.Get("insecure").(bool),
+		CACertFile:        d.Get("cacert_file").(string),
+		ClientCertFile:    d.Get("clientcert_file").(string),
+		ClientKeyFile:     d.Get("clientkey_file").(string),
+		Proxy:             d.Get("proxy").(string),
+		RequestTimeout:    d.Get("request_timeout").(int),
+		DialTimeout:       d.Get("dial_timeout").(int),
+		MaxRetries:        d.Get("max_retries").(int),
+		RetryMinWait:      d.Get("retry_min_wait").(int),
+		RetryMaxWait:      d.Get("retry_max_wait").(int),
+	}
+
+	return config.Client()
+}
+
+// Settings of the provider block.
+type providerConfig struct {
+	Endpoint string
+
+	// Management interfaces of the cluster nodes, used instead of Endpoint.
+	Endpoints         []string
+	EndpointSelection string
+
+	Username       string
+	Password       string
+	OAuth2         *oauth2Config
+	Insecure       bool
+	CACertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
+	Proxy          string
+
+	// Timeouts in seconds, zero disables them.
+	RequestTimeout int
+	DialTimeout    int
+
+	// Retries of requests failing with transient errors, waits in seconds.
+	MaxRetries   int
+	RetryMinWait int
+	RetryMaxWait int
+}
+
+// Bearer token authentication through the OAuth 2.0 plugin of RabbitMQ.
+type oauth2Config struct {
+	Token string
+
+	// Client credentials flow, used when no token is given.
+	ClientID      string
+	ClientSecret  string
+	TokenEndpoint string
+	Scopes        []string
+}
+
+/*
+Returns the source of the bearer tokens, refreshing them before they expire.
+Tokens are requested through the transport of the management interface
+so that the TLS and proxy settings apply.
+*/
+func (c *oauth2Config) TokenSource(transport http.RoundTripper) (oauth2.TokenSource, error) {
+
+	if c.Token != "" && (c.ClientID != "" || c.ClientSecret != "" || c.TokenEndpoint != "") {
+		return nil, fmt.Errorf("The oauth2 argument \"token\" conflicts with \"client_id\", \"client_secret\" and \"token_endpoint\"")
+	}
+
+	if c.Token != "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token}), nil
+	}
+
+	if c.ClientID == "" || c.ClientSecret == "" || c.TokenEndpoint == "" {
+		return nil, fmt.Errorf("The oauth2 block requires either \"token\" or \"client_id\", \"client_secret\" and \"token_endpoint\"")
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		TokenURL:     c.TokenEndpoint,
+		Scopes:       c.Scopes,
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: transport})
+
+	return config.TokenSource(ctx), nil
+}
+
+const (
+	// Seconds to wait for a connection to the management interface by default.
+	defaultDialTimeout = 30
+
+	// Seconds the health check of an endpoint may take at most.
+	maxHealthCheckTimeout = 10
+
+	defaultMaxRetries   = 3
+	defaultRetryMinWait = 1
+	defaultRetryMaxWait = 30
+)
+
+/*
+Returns the timeout of the health check of each endpoint, which is bounded
+even when the dial timeout is disabled, since an endpoint accepting
+connections without answering would block the configuration of the provider.
+*/
+func healthCheckTimeout(dialTimeout int) time.Duration {
+	if dialTimeout > 0 && dialTimeout < maxHealthCheckTimeout {
+		return time.Duration(dialTimeout) * time.Second
+	}
+
+	return maxHealthCheckTimeout * time.Second
+}
+
+// Builds the client for the RabbitMQ management interface.
+func (c providerConfig) Client() (*rabbitmqClient, error) {
+
+	endpoints := c.Endpoints
+	if len(endpoints) == 0 && c.Endpoint != "" {
+		endpoints = []string{c.Endpoint}
+	}
+
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("One of the provider arguments \"endpoint\" or \"endpoints\" is required")
+	}
+
+	if c.OAuth2 == nil && c.Username == "" {
+		return nil, fmt.Errorf("The provider argument \"username\" is required unless \"oauth2\" is configured")
+	}
+
+	if c.OAuth2 == nil && c.Password == "" {
+		return nil, fmt.Errorf("The provider argument \"password\" is required unless \"oauth2\" is configured")
+	}
 
 	// Configure TLS/SSL:
 	// Ignore self-signed cert warnings
 	// Specify a custom CA / intermediary cert
 	// Specify a certificate and key
 	tlsConfig := &tls.Config{}
-	if cacertFile != "" {
-		caCert, err := ioutil.ReadFile(cacertFile)
+	if c.CACertFile != "" {
+		caCert, err := ioutil.ReadFile(c.CACertFile)
 		if err != nil {
 			return nil, err
 		}
@@ -142,28 +407,33 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		caCertPool.AppendCertsFromPEM(caCert)
 		tlsConfig.RootCAs = caCertPool
 	}
-	if clientcertFile != "" && clientkeyFile != "" {
-		clientPair, err := tls.LoadX509KeyPair(clientcertFile, clientkeyFile)
+	if c.ClientCertFile != "" && c.ClientKeyFile != "" {
+		clientPair, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
 		if err != nil {
 			return nil, err
 		}
 		tlsConfig.Certificates = []tls.Certificate{clientPair}
 	}
-	if insecure {
+	if c.Insecure {
 		tlsConfig.InsecureSkipVerify = true
 	}
 
 	var proxyURL *url.URL
-	if proxy != "" {
+	if c.Proxy != "" {
 		var err error
-		proxyURL, err = url.Parse(proxy)
+		proxyURL, err = url.Parse(c.Proxy)
 		if err != nil {
-			return nil, fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", c.Proxy, err)
 		}
 	}
 
 	// Connect to RabbitMQ management interface
+	dialer := &net.Dialer{
+		Timeout: time.Duration(c.DialTimeout) * time.Second,
+	}
+
 	transport := &http.Transport{
+		DialContext:     dialer.DialContext,
 		TLSClientConfig: tlsConfig,
 		Proxy: func(req *http.Request) (*url.URL, error) {
 			if proxyURL != nil {
@@ -174,10 +444,43 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		},
 	}
 
-	rmqc, err := rabbithole.NewTLSClient(endpoint, username, password, transport)
+	var base http.RoundTripper = transport
+	if len(endpoints) > 1 {
+		failover, err := newFailoverTransport(transport, endpoints, c.EndpointSelection == "round_robin")
+		if err != nil {
+			return nil, err
+		}
+
+		if c.EndpointSelection != "round_robin" {
+			failover.selectHealthy(healthCheckTimeout(c.DialTimeout))
+		}
+
+		base = failover
+	}
+
+	// Bearer tokens replace the basic authentication set by rabbit-hole.
+	if c.OAuth2 != nil {
+		tokenSource, err := c.OAuth2.TokenSource(transport)
+		if err != nil {
+			return nil, err
+		}
+
+		base = &oauth2.Transport{Source: tokenSource, Base: base}
+	}
+
+	retry := &retryTransport{
+		transport:  base,
+		maxRetries: c.MaxRetries,
+		minWait:    time.Duration(c.RetryMinWait) * time.Second,
+		maxWait:    time.Duration(c.RetryMaxWait) * time.Second,
+	}
+
+	rmqc, err := rabbithole.NewTLSClient(endpoints[0], c.Username, c.Password, retry)
 	if err != nil {
 		return nil, err
 	}
 
-	return rmqc, nil
+	rmqc.SetTimeout(time.Duration(c.RequestTimeout) * time.Second)
+
+	return &rabbitmqClient{Client: rmqc, transport: retry, timeout: time.Duration(c.RequestTimeout) * time.Second}, nil
 }
@@ -1,12 +1,14 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,12 +18,17 @@ import (
 
 func resourceBinding() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateBinding,
-		Read:   ReadBinding,
-		Delete: DeleteBinding,
+		CreateContext: CreateBinding,
+		ReadContext:   ReadBinding,
+		DeleteContext: DeleteBinding,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"source": {
@@ -99,8 +106,8 @@ func getBindingById(parts []string, bindings []rabbithole.BindingInfo) (rabbitho
 	return rabbithole.BindingInfo{}, fmt.Errorf("binding cannot be found")
 }
 
-func CreateBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateBinding(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	vhost := d.Get("vhost").(string)
 	arguments := d.Get("arguments").(map[string]interface{})
@@ -111,7 +118,7 @@ func CreateBinding(d *schema.ResourceData, meta interface{}) error {
 		var arguments_json map[string]interface{}
 		err := json.Unmarshal([]byte(v), &arguments_json)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		arguments = arguments_json
@@ -135,7 +142,7 @@ func CreateBinding(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Binding properties key: %s", propertiesKey)
@@ -145,24 +152,24 @@ func CreateBinding(d *schema.ResourceData, meta interface{}) error {
 	// Use the composite id structure to solve the following bug: https://github.com/cyrilgdn/terraform-provider-rabbitmq/issues/25
 	d.SetId(fmt.Sprintf("%s#%s#%s#%s#%s", percentEncodeSlashes(vhost), bindingInfo.DestinationType, bindingInfo.PropertiesKey, d.Get("source"), d.Get("destination")))
 
-	return ReadBinding(d, meta)
+	return ReadBinding(ctx, d, meta)
 }
 
-func ReadBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadBinding(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	log.Printf("[TRACE] RabbitMQ: read binding resource ID (pre-split): %s", d.Id())
 	bindingId := strings.Split(d.Id(), "#")
 	log.Printf("[DEBUG] RabbitMQ: binding ID: %#v", bindingId)
 	if len(bindingId) < 5 {
-		return fmt.Errorf("Unable to determine binding ID")
+		return diag.Errorf("Unable to determine binding ID")
 	}
 
 	vhost := percentDecodeSlashes(bindingId[0])
 
 	bindings, err := rmqc.ListBindingsIn(vhost)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Bindings retrieved: %#v", bindings)
@@ -183,7 +190,7 @@ func ReadBinding(d *schema.ResourceData, meta interface{}) error {
 		if v, ok := d.Get("arguments_json").(string); ok && v != "" {
 			bytes, err := json.Marshal(binding.Arguments)
 			if err != nil {
-				return fmt.Errorf("could not encode arguments as JSON: %w", err)
+				return diag.FromErr(fmt.Errorf("could not encode arguments as JSON: %w", err))
 			}
 			d.Set("arguments_json", string(bytes))
 		} else {
@@ -199,12 +206,12 @@ func ReadBinding(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func DeleteBinding(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteBinding(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	bindingId := strings.Split(d.Id(), "#")
 	if len(bindingId) < 5 {
-		return fmt.Errorf("Unable to determine binding ID")
+		return diag.Errorf("Unable to determine binding ID")
 	}
 
 	vhost := percentDecodeSlashes(bindingId[0])
@@ -226,7 +233,7 @@ func DeleteBinding(d *schema.ResourceData, meta interface{}) error {
 
 	resp, err := rmqc.DeleteBinding(vhost, bindingInfo)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Binding delete response: %#v", resp)
@@ -237,7 +244,7 @@ func DeleteBinding(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ binding: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ binding: %s", resp.Status)
 	}
 
 	return nil
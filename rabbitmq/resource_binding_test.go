@@ -95,7 +95,7 @@ func testAccBindingCheck(rn string, bindingInfo *rabbithole.BindingInfo) resourc
 			return fmt.Errorf("binding id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		bindingParts := strings.Split(rs.Primary.ID, "#")
 
 		bindings, err := rmqc.ListBindingsIn(percentDecodeSlashes(bindingParts[0]))
@@ -140,7 +140,7 @@ func testAccBindingCheckJsonArguments(rn string, bindingInfo *rabbithole.Binding
 
 func testAccBindingCheckDestroy(bindingInfo rabbithole.BindingInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 		bindings, err := rmqc.ListBindingsIn(bindingInfo.Vhost)
 		if err != nil {
@@ -0,0 +1,269 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceDefinitions() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateDefinitions,
+		UpdateContext: UpdateDefinitions,
+		ReadContext:   ReadDefinitions,
+		DeleteContext: DeleteDefinitions,
+		Importer: &schema.ResourceImporter{
+			StateContext: ImportDefinitions,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		CustomizeDiff: customizeDefinitionsDiff,
+
+		Schema: map[string]*schema.Schema{
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// Refreshed from the live definitions of the declared objects,
+			// so that drift shows up as a difference with the configuration.
+			"definitions": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressEquivalentDefinitions,
+			},
+
+			"drift": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func CreateDefinitions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vhost := d.Get("vhost").(string)
+
+	defs, err := parseDefinitionsJSON(d.Get("definitions").(string), vhost, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := uploadDefinitions(ctx, meta.(*rabbitmqClient), vhost, defs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if vhost == "" {
+		d.SetId("definitions")
+	} else {
+		d.SetId(fmt.Sprintf("definitions@%s", vhost))
+	}
+
+	return ReadDefinitions(ctx, d, meta)
+}
+
+/*
+Imported definitions start without a document, so that the first apply
+adopts the configured document without deleting any live object.
+*/
+func ImportDefinitions(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+
+	switch {
+	case id == "definitions":
+		d.Set("vhost", "")
+
+	case strings.HasPrefix(id, "definitions@") && len(id) > len("definitions@"):
+		d.Set("vhost", strings.TrimPrefix(id, "definitions@"))
+
+	default:
+		return nil, fmt.Errorf("Unable to determine definitions ID: %s, expected definitions or definitions@vhost", id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func ReadDefinitions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vhost := d.Get("vhost").(string)
+
+	declared, err := parseDefinitionsJSON(d.Get("definitions").(string), vhost, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var document map[string]interface{}
+	err = meta.(*rabbitmqClient).executeJSONRequest(ctx, http.MethodGet, definitionsPath(vhost), nil, &document)
+	if err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	live, err := parseDefinitions(document, vhost, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, drift := projectDefinitions(declared, live)
+
+	ids := make([]string, 0, len(drift))
+	for id := range drift {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		log.Printf("[WARN] RabbitMQ: Definitions of %s drifted, %s: %s", d.Id(), id, drift[id])
+	}
+
+	raw, err := json.Marshal(current.document())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("definitions", string(raw))
+	d.Set("drift", drift)
+
+	return nil
+}
+
+func UpdateDefinitions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient)
+	vhost := d.Get("vhost").(string)
+
+	if d.HasChange("definitions") {
+		oldDocument, newDocument := d.GetChange("definitions")
+
+		oldDefs, err := parseDefinitionsJSON(oldDocument.(string), vhost, false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		newDefs, err := parseDefinitionsJSON(newDocument.(string), vhost, true)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// objects removed from the document are deleted
+		removed := make(definitions)
+		for section, objects := range oldDefs {
+			for _, object := range objects {
+				if _, ok := newDefs.find(section, object.id); !ok {
+					removed[section] = append(removed[section], object)
+				}
+			}
+		}
+
+		if err := deleteDefinitions(rmqc.WithContext(ctx), removed, vhost); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := uploadDefinitions(ctx, rmqc, vhost, newDefs); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadDefinitions(ctx, d, meta)
+}
+
+func DeleteDefinitions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+	vhost := d.Get("vhost").(string)
+
+	defs, err := parseDefinitionsJSON(d.Get("definitions").(string), vhost, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete definitions for %s", d.Id())
+
+	if err := deleteDefinitions(rmqc, defs, vhost); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func customizeDefinitionsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Unknown values are checked again once they are known.
+	if !d.NewValueKnown("definitions") || !d.NewValueKnown("vhost") {
+		return nil
+	}
+
+	vhost := d.Get("vhost").(string)
+
+	newDefs, err := parseDefinitionsJSON(d.Get("definitions").(string), vhost, true)
+	if err != nil {
+		return err
+	}
+
+	// the prior document holds the live definitions of the declared objects
+	if d.Id() == "" || !d.HasChange("definitions") {
+		return nil
+	}
+
+	oldDocument, _ := d.GetChange("definitions")
+
+	oldDefs, err := parseDefinitionsJSON(oldDocument.(string), vhost, false)
+	if err != nil {
+		return nil
+	}
+
+	return immutableDefinitionsChanges(oldDefs, newDefs)
+}
+
+func suppressEquivalentDefinitions(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+
+	vhost := d.Get("vhost").(string)
+
+	oldDefs, err := parseDefinitionsJSON(old, vhost, false)
+	if err != nil {
+		return false
+	}
+
+	newDefs, err := parseDefinitionsJSON(new, vhost, false)
+	if err != nil {
+		return false
+	}
+
+	return equivalentDefinitions(oldDefs, newDefs)
+}
+
+func uploadDefinitions(ctx context.Context, rmqc *rabbitmqClient, vhost string, defs definitions) error {
+	log.Printf("[DEBUG] RabbitMQ: Attempting to upload definitions to %s", definitionsPath(vhost))
+
+	err := rmqc.executeJSONRequest(ctx, http.MethodPost, definitionsPath(vhost), defs.document(), nil)
+
+	// live objects declared with other immutable fields are only detected when applying
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && strings.Contains(errorResponse.Reason, "PRECONDITION_FAILED") {
+		return fmt.Errorf("Error uploading RabbitMQ definitions: an existing exchange or queue was declared with other immutable fields, "+
+			"e.g. its type, durability or arguments, and must be deleted before it can be declared again: %w", err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("Error uploading RabbitMQ definitions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDefinitions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccDefinitionsCheckDestroy("test", "orders", "invoices"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefinitionsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDefinitionsCheckQueue("test", "orders", true),
+					testAccDefinitionsCheckQueue("test", "invoices", true),
+					resource.TestCheckResourceAttr("rabbitmq_definitions.test", "drift.%", "0"),
+				),
+			},
+			{
+				// a deleted queue is detected and declared again
+				PreConfig: func() {
+					rmqc := testAccProvider.Meta().(*rabbitmqClient)
+					if _, err := rmqc.DeleteQueue("test", "orders"); err != nil {
+						t.Fatalf("err: %s", err)
+					}
+				},
+				Config: testAccDefinitionsConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDefinitionsCheckQueue("test", "orders", true),
+					resource.TestCheckResourceAttr("rabbitmq_definitions.test", "drift.%", "0"),
+				),
+			},
+			{
+				Config: testAccDefinitionsConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccDefinitionsCheckQueue("test", "orders", true),
+					testAccDefinitionsCheckQueue("test", "invoices", false),
+				),
+			},
+		},
+	})
+}
+
+func TestDefinitionsImmutableChanges(t *testing.T) {
+	old := `{"queues": [{"name": "orders", "durable": true, "auto_delete": false, "arguments": {"x-queue-type": "classic"}}]}`
+
+	for name, test := range map[string]struct {
+		definitions string
+		valid       bool
+	}{
+		"unchanged": {
+			definitions: old,
+			valid:       true,
+		},
+		"new queue": {
+			definitions: `{"queues": [{"name": "invoices", "durable": false, "auto_delete": false, "arguments": {}}]}`,
+			valid:       true,
+		},
+		"arguments": {
+			definitions: `{"queues": [{"name": "orders", "durable": true, "auto_delete": false, "arguments": {"x-queue-type": "quorum"}}]}`,
+		},
+		"durable": {
+			definitions: `{"queues": [{"name": "orders", "durable": false, "auto_delete": false, "arguments": {"x-queue-type": "classic"}}]}`,
+		},
+	} {
+		state := &terraform.InstanceState{
+			ID: "definitions@test",
+			Attributes: map[string]string{
+				"id":          "definitions@test",
+				"vhost":       "test",
+				"definitions": old,
+			},
+		}
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"vhost":       "test",
+			"definitions": test.definitions,
+		})
+
+		_, err := resourceDefinitions().Diff(context.Background(), state, config, nil)
+
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCheckDefinitionsDeleteResponse(t *testing.T) {
+	for name, test := range map[string]struct {
+		resp  *http.Response
+		err   error
+		valid bool
+	}{
+		"deleted":         {resp: &http.Response{StatusCode: http.StatusNoContent, Status: "204 No Content"}, valid: true},
+		"already deleted": {resp: &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found"}, valid: true},
+		"nothing deleted": {valid: true},
+		"forbidden":       {resp: &http.Response{StatusCode: http.StatusForbidden, Status: "403 Forbidden"}},
+		"server error":    {resp: &http.Response{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error"}},
+		"request error":   {err: errors.New("connection refused")},
+	} {
+		err := checkDefinitionsDeleteResponse(test.resp, test.err)
+
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func testAccDefinitionsCheckQueue(vhost string, name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		_, err := rmqc.GetQueue(vhost, name)
+		if exists && err != nil {
+			return fmt.Errorf("Error retrieving queue %s@%s: %s", name, vhost, err)
+		}
+
+		if !exists && err == nil {
+			return fmt.Errorf("Queue %s@%s still exists", name, vhost)
+		}
+
+		return nil
+	}
+}
+
+func testAccDefinitionsCheckDestroy(vhost string, queues ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, queue := range queues {
+			if err := testAccDefinitionsCheckQueue(vhost, queue, false)(s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccDefinitionsConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_definitions" "test" {
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    definitions = jsonencode({
+        exchanges = [
+            { name = "orders", type = "topic", durable = true, auto_delete = false, internal = false, arguments = {} },
+        ]
+        queues = [
+            { name = "orders", durable = true, auto_delete = false, arguments = { "x-queue-type" = "classic" } },
+            { name = "invoices", durable = true, auto_delete = false, arguments = {} },
+        ]
+        bindings = [
+            { source = "orders", destination = "orders", destination_type = "queue", routing_key = "#", arguments = {} },
+        ]
+    })
+}`
+
+const testAccDefinitionsConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_definitions" "test" {
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    definitions = jsonencode({
+        exchanges = [
+            { name = "orders", type = "topic", durable = true, auto_delete = false, internal = false, arguments = {} },
+        ]
+        queues = [
+            { name = "orders", durable = true, auto_delete = false, arguments = { "x-queue-type" = "classic" } },
+        ]
+        bindings = [
+            { source = "orders", destination = "orders", destination_type = "queue", routing_key = "#", arguments = {} },
+        ]
+    })
+}`
@@ -0,0 +1,568 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+/*
+Section of a definitions document along with the keys that identify
+its objects. Sections are listed in the order objects are declared,
+objects are deleted in the reverse order.
+*/
+type definitionsSection struct {
+	name string
+	keys []string
+
+	// whether the section can be part of the definitions of a single vhost
+	vhostScoped bool
+}
+
+var definitionsSections = []definitionsSection{
+
+	{name: "vhosts", keys: []string{"name"}},
+	{name: "users", keys: []string{"name"}},
+	{name: "permissions", keys: []string{"user", "vhost"}},
+	{name: "topic_permissions", keys: []string{"user", "vhost", "exchange"}},
+	{name: "global_parameters", keys: []string{"name"}},
+	{name: "parameters", keys: []string{"vhost", "component", "name"}, vhostScoped: true},
+	{name: "policies", keys: []string{"vhost", "name"}, vhostScoped: true},
+	{name: "exchanges", keys: []string{"vhost", "name"}, vhostScoped: true},
+	{name: "queues", keys: []string{"vhost", "name"}, vhostScoped: true},
+	{name: "bindings", keys: []string{"vhost", "source", "destination", "destination_type", "routing_key", "arguments"}, vhostScoped: true},
+}
+
+/*
+Fields of the objects that RabbitMQ cannot change once declared. Uploading
+a different value fails with a PRECONDITION_FAILED error.
+*/
+var definitionsImmutableFields = map[string][]string{
+
+	"exchanges": {"type", "durable", "auto_delete", "internal", "arguments"},
+	"queues":    {"type", "durable", "auto_delete", "arguments"},
+}
+
+// Keys describing the exporting node rather than definitions.
+var definitionsMetadataKeys = map[string]bool{
+
+	"rabbit_version":   true,
+	"rabbitmq_version": true,
+	"product_name":     true,
+	"product_version":  true,
+}
+
+// Object of a definitions document and its identifier, e.g. queues/vhost=/,name=orders.
+type definitionsObject struct {
+	id     string
+	fields map[string]interface{}
+}
+
+// Objects of a definitions document per section, in document order.
+type definitions map[string][]definitionsObject
+
+/*
+Parses a definitions document. When scoped to a vhost, the vhost of
+each object is left out since vhost definitions do not carry it.
+In strict mode, sections that cannot be applied are rejected.
+*/
+func parseDefinitions(document map[string]interface{}, vhost string, strict bool) (definitions, error) {
+
+	known := make(map[string]bool)
+	result := make(definitions)
+
+	for _, section := range definitionsSections {
+
+		known[section.name] = true
+
+		value, ok := document[section.name]
+
+		if !ok || value == nil {
+
+			continue
+		}
+
+		objects, ok := value.([]interface{})
+
+		if !ok {
+
+			return nil, fmt.Errorf("definitions section %q must be a list of objects", section.name)
+		}
+
+		if len(objects) > 0 && vhost != "" && !section.vhostScoped {
+
+			if strict {
+
+				return nil, fmt.Errorf("definitions section %q cannot be applied to vhost %q, only %s can", section.name, vhost, definitionsVhostSections())
+			}
+
+			continue
+		}
+
+		seen := make(map[string]bool)
+
+		for _, object := range objects {
+
+			fields, ok := object.(map[string]interface{})
+
+			if !ok {
+
+				return nil, fmt.Errorf("definitions section %q must be a list of objects", section.name)
+			}
+
+			if vhost != "" {
+
+				if v, ok := fields["vhost"]; ok && v != vhost && strict {
+
+					return nil, fmt.Errorf("definitions of vhost %q cannot declare %s in vhost %v", vhost, section.name, v)
+				}
+
+				fields = copyWithout(fields, "vhost")
+			}
+
+			id := definitionsObjectID(section, fields)
+
+			if seen[id] && strict {
+
+				return nil, fmt.Errorf("definitions declare %s more than once", id)
+			}
+
+			seen[id] = true
+
+			result[section.name] = append(result[section.name], definitionsObject{id: id, fields: fields})
+		}
+	}
+
+	if strict {
+
+		for key := range document {
+
+			if !known[key] && !definitionsMetadataKeys[key] {
+
+				return nil, fmt.Errorf("unsupported definitions section %q", key)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+/*
+Parses a definitions document given as a JSON string. Imported
+definitions have no document yet, which declares no object.
+*/
+func parseDefinitionsJSON(document string, vhost string, strict bool) (definitions, error) {
+
+	if document == "" {
+
+		return make(definitions), nil
+	}
+
+	var raw map[string]interface{}
+
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+
+		return nil, fmt.Errorf("invalid definitions JSON: %w", err)
+	}
+
+	return parseDefinitions(raw, vhost, strict)
+}
+
+func definitionsVhostSections() string {
+
+	var names []string
+
+	for _, section := range definitionsSections {
+
+		if section.vhostScoped {
+
+			names = append(names, section.name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Returns an identifier built from the keys of the section, e.g. queues/vhost=/,name=orders.
+func definitionsObjectID(section definitionsSection, fields map[string]interface{}) string {
+
+	var parts []string
+
+	for _, key := range section.keys {
+
+		// empty values are left out as they may be omitted from the document
+		switch value := fields[key].(type) {
+
+		case nil:
+			continue
+
+		case string:
+			if value == "" {
+
+				continue
+			}
+
+		case map[string]interface{}:
+			if len(value) == 0 {
+
+				continue
+			}
+		}
+
+		value := fields[key]
+
+		if arguments, ok := value.(map[string]interface{}); ok {
+
+			value = toString(arguments)
+		}
+
+		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	}
+
+	return fmt.Sprintf("%s/%s", section.name, strings.Join(parts, ","))
+}
+
+func copyWithout(fields map[string]interface{}, key string) map[string]interface{} {
+
+	result := make(map[string]interface{}, len(fields))
+
+	for k, v := range fields {
+
+		if k != key {
+
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// Returns the objects as a document that can be uploaded.
+func (defs definitions) document() map[string]interface{} {
+
+	document := make(map[string]interface{})
+
+	for _, section := range definitionsSections {
+
+		objects, ok := defs[section.name]
+
+		if !ok {
+
+			continue
+		}
+
+		list := make([]interface{}, 0, len(objects))
+
+		for _, object := range objects {
+
+			list = append(list, object.fields)
+		}
+
+		document[section.name] = list
+	}
+
+	return document
+}
+
+func (defs definitions) find(section string, id string) (definitionsObject, bool) {
+
+	for _, object := range defs[section] {
+
+		if object.id == id {
+
+			return object, true
+		}
+	}
+
+	return definitionsObject{}, false
+}
+
+/*
+Restricts the live definitions to the declared objects and their
+declared fields, and returns the drift of each object. Fields the
+management interface does not return, e.g. passwords, are assumed
+to be unchanged.
+*/
+func projectDefinitions(declared definitions, live definitions) (definitions, map[string]string) {
+
+	result := make(definitions)
+	drift := make(map[string]string)
+
+	for _, section := range definitionsSections {
+
+		for _, object := range declared[section.name] {
+
+			liveObject, ok := live.find(section.name, object.id)
+
+			if !ok {
+
+				drift[object.id] = "missing"
+
+				continue
+			}
+
+			fields := make(map[string]interface{}, len(object.fields))
+
+			var changed []string
+
+			for key, value := range object.fields {
+
+				liveValue, ok := liveObject.fields[key]
+
+				if !ok {
+
+					fields[key] = value
+
+					continue
+				}
+
+				fields[key] = liveValue
+
+				if !reflect.DeepEqual(value, liveValue) {
+
+					changed = append(changed, key)
+				}
+			}
+
+			if len(changed) > 0 {
+
+				sort.Strings(changed)
+
+				drift[object.id] = fmt.Sprintf("changed: %s", strings.Join(changed, ", "))
+			}
+
+			result[section.name] = append(result[section.name], definitionsObject{id: object.id, fields: fields})
+		}
+	}
+
+	return result, drift
+}
+
+// Returns whether both documents declare the same objects, regardless of their order.
+func equivalentDefinitions(a definitions, b definitions) bool {
+
+	for _, section := range definitionsSections {
+
+		if len(a[section.name]) != len(b[section.name]) {
+
+			return false
+		}
+
+		for _, object := range a[section.name] {
+
+			other, ok := b.find(section.name, object.id)
+
+			if !ok || !reflect.DeepEqual(object.fields, other.fields) {
+
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+/*
+Returns an error describing the objects declared by both documents whose
+immutable fields differ, since they can only be declared again once
+deleted.
+*/
+func immutableDefinitionsChanges(old definitions, new definitions) error {
+
+	var changes []string
+
+	for _, section := range definitionsSections {
+
+		fields, ok := definitionsImmutableFields[section.name]
+
+		if !ok {
+
+			continue
+		}
+
+		for _, object := range new[section.name] {
+
+			oldObject, ok := old.find(section.name, object.id)
+
+			if !ok {
+
+				continue
+			}
+
+			var changed []string
+
+			for _, field := range fields {
+
+				if !reflect.DeepEqual(oldObject.fields[field], object.fields[field]) {
+
+					changed = append(changed, field)
+				}
+			}
+
+			if len(changed) > 0 {
+
+				changes = append(changes, fmt.Sprintf("%s (%s)", object.id, strings.Join(changed, ", ")))
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+
+		return fmt.Errorf("definitions cannot change the immutable fields of %s: remove these objects from the document and apply it before declaring them again", strings.Join(changes, ", "))
+	}
+
+	return nil
+}
+
+// Returns the path of the definitions endpoint for the cluster or a vhost.
+func definitionsPath(vhost string) string {
+
+	if vhost == "" {
+
+		return "definitions"
+	}
+
+	return "definitions/" + url.PathEscape(vhost)
+}
+
+/*
+Deletes the objects through the endpoint of each type, in the reverse
+order of declaration. Objects that no longer exist are ignored.
+*/
+func deleteDefinitions(rmqc *rabbithole.Client, defs definitions, vhost string) error {
+
+	for i := len(definitionsSections) - 1; i >= 0; i-- {
+
+		section := definitionsSections[i]
+
+		for _, object := range defs[section.name] {
+
+			log.Printf("[DEBUG] RabbitMQ: Attempting to delete %s", object.id)
+
+			if err := deleteDefinitionsObject(rmqc, section.name, object.fields, vhost); err != nil {
+
+				return fmt.Errorf("Error deleting %s: %w", object.id, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func deleteDefinitionsObject(rmqc *rabbithole.Client, section string, fields map[string]interface{}, vhost string) error {
+
+	field := func(key string) string {
+
+		if key == "vhost" && vhost != "" {
+
+			return vhost
+		}
+
+		if value, ok := fields[key].(string); ok {
+
+			return value
+		}
+
+		return ""
+	}
+
+	var resp *http.Response
+	var err error
+
+	switch section {
+
+	case "vhosts":
+		resp, err = rmqc.DeleteVhost(field("name"))
+
+	case "users":
+		resp, err = rmqc.DeleteUser(field("name"))
+
+	case "permissions":
+		resp, err = rmqc.ClearPermissionsIn(field("vhost"), field("user"))
+
+	case "topic_permissions":
+		resp, err = rmqc.DeleteTopicPermissionsIn(field("vhost"), field("user"), field("exchange"))
+
+	case "global_parameters":
+		resp, err = rmqc.DeleteGlobalParameter(field("name"))
+
+	case "parameters":
+		resp, err = rmqc.DeleteRuntimeParameter(field("component"), field("vhost"), field("name"))
+
+	case "policies":
+		resp, err = rmqc.DeletePolicy(field("vhost"), field("name"))
+
+	case "exchanges":
+		resp, err = rmqc.DeleteExchange(field("vhost"), field("name"))
+
+	case "queues":
+		resp, err = rmqc.DeleteQueue(field("vhost"), field("name"))
+
+	case "bindings":
+		resp, err = deleteDefinitionsBinding(rmqc, fields, field("vhost"))
+	}
+
+	return checkDefinitionsDeleteResponse(resp, err)
+}
+
+// Fails on error responses, the objects that no longer exist are already deleted.
+func checkDefinitionsDeleteResponse(resp *http.Response, err error) error {
+
+	if err != nil {
+
+		return err
+	}
+
+	if resp == nil || resp.StatusCode == http.StatusNotFound {
+
+		return nil
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+
+		return fmt.Errorf("%s", resp.Status)
+	}
+
+	return nil
+}
+
+// Bindings are deleted through their properties key, looked up among the live bindings.
+func deleteDefinitionsBinding(rmqc *rabbithole.Client, fields map[string]interface{}, vhost string) (*http.Response, error) {
+
+	bindings, err := rmqc.ListBindingsIn(vhost)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	arguments, _ := fields["arguments"].(map[string]interface{})
+
+	for _, binding := range bindings {
+
+		if binding.Source != fields["source"] || binding.Destination != fields["destination"] ||
+			binding.DestinationType != fields["destination_type"] || binding.RoutingKey != fields["routing_key"] {
+
+			continue
+		}
+
+		if len(binding.Arguments) > 0 || len(arguments) > 0 {
+
+			if toString(binding.Arguments) != toString(arguments) {
+
+				continue
+			}
+		}
+
+		return rmqc.DeleteBinding(vhost, binding)
+	}
+
+	return nil, nil
+}
@@ -1,9 +1,11 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
@@ -11,12 +13,17 @@ import (
 
 func resourceExchange() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateExchange,
-		Read:   ReadExchange,
-		Delete: DeleteExchange,
+		CreateContext: CreateExchange,
+		ReadContext:   ReadExchange,
+		DeleteContext: DeleteExchange,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -67,8 +74,8 @@ func resourceExchange() *schema.Resource {
 	}
 }
 
-func CreateExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateExchange(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -76,7 +83,7 @@ func CreateExchange(d *schema.ResourceData, meta interface{}) error {
 
 	settingsMap, ok := settingsList[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("Unable to parse settings")
+		return diag.Errorf("Unable to parse settings")
 	}
 
 	d.SetId(fmt.Sprintf("%s@%s@%s", name, vhost, toString(settingsMap)))
@@ -85,25 +92,25 @@ func CreateExchange(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
-	return ReadExchange(d, meta)
+	return ReadExchange(ctx, d, meta)
 }
 
-func ReadExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadExchange(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name, vhost, _, err := parseIdWithArgs(d.Id())
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	exchangeSettings, err := rmqc.GetExchange(vhost, name)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Exchange retrieved %s: %#v", d.Id(), exchangeSettings)
@@ -123,14 +130,14 @@ func ReadExchange(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func DeleteExchange(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteExchange(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name, vhost, _, err := parseIdWithArgs(d.Id())
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete exchange %s", d.Id())
@@ -138,7 +145,7 @@ func DeleteExchange(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.DeleteExchange(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: Exchange delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -147,7 +154,7 @@ func DeleteExchange(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ exchange: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ exchange: %s", resp.Status)
 	}
 
 	return nil
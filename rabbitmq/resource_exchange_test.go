@@ -39,7 +39,7 @@ func testAccExchangeCheck(rn string, exchangeInfo *rabbithole.ExchangeInfo) reso
 			return fmt.Errorf("exchange id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		exchParts := strings.Split(rs.Primary.ID, "@")
 
 		exchanges, err := rmqc.ListExchangesIn(exchParts[1])
@@ -60,7 +60,7 @@ func testAccExchangeCheck(rn string, exchangeInfo *rabbithole.ExchangeInfo) reso
 
 func testAccExchangeCheckDestroy(exchangeInfo *rabbithole.ExchangeInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 		exchanges, err := rmqc.ListExchangesIn(exchangeInfo.Vhost)
 		if err != nil {
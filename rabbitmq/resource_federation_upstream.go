@@ -1,9 +1,11 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
@@ -11,13 +13,21 @@ import (
 
 func resourceFederationUpstream() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateFederationUpstream,
-		Read:   ReadFederationUpstream,
-		Update: UpdateFederationUpstream,
-		Delete: DeleteFederationUpstream,
+		CreateContext: CreateFederationUpstream,
+		ReadContext:   ReadFederationUpstream,
+		UpdateContext: UpdateFederationUpstream,
+		DeleteContext: DeleteFederationUpstream,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		CustomizeDiff: customizeFederationUpstreamDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -38,6 +48,25 @@ func resourceFederationUpstream() *schema.Resource {
 				Computed: true,
 			},
 
+			// Waits for the links of the upstream to run after creating or
+			// updating it, within the create and update timeouts.
+			"wait_for_link": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Restricts the definition to the settings applicable to
+			// federated exchanges or queues.
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					federationModeExchange,
+					federationModeQueue,
+				}, false),
+			},
+
 			"definition": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -100,11 +129,50 @@ func resourceFederationUpstream() *schema.Resource {
 							Type:     schema.TypeInt,
 							Optional: true,
 						},
+
+						// the type of the internal upstream queue
+						"queue_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								queueTypeClassic,
+								queueTypeQuorum,
+							}, false),
+						},
+
+						"bind_nowait": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+
+						"channel_use_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"multiple",
+								"single",
+							}, false),
+						},
+
+						"resource_cleanup_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"default",
+								"never",
+							}, false),
+						},
 						// applicable to federated queues only
 						"queue": {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+
+						"consumer_tag": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
 					},
 				},
 			},
@@ -112,8 +180,8 @@ func resourceFederationUpstream() *schema.Resource {
 	}
 }
 
-func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateFederationUpstream(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -121,30 +189,34 @@ func CreateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 
 	defMap, ok := defList[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("Unable to parse federation upstream definition")
+		return diag.Errorf("Unable to parse federation upstream definition")
 	}
 
 	if err := putFederationUpstream(rmqc, vhost, name, defMap); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
-	return ReadFederationUpstream(d, meta)
-}
+	if d.Get("wait_for_link").(bool) {
+		if err := waitForFederationLink(ctx, meta.(*rabbitmqClient), vhost, name, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
-func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+	return ReadFederationUpstream(ctx, d, meta)
+}
 
+func ReadFederationUpstream(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name, vhost, _, err := parseIdWithArgs(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	upstream, err := rmqc.GetFederationUpstream(vhost, name)
+	upstream, err := getFederationUpstream(ctx, meta.(*rabbitmqClient), vhost, name)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Federation upstream retrieved for %s: %#v", d.Id(), upstream)
@@ -152,22 +224,30 @@ func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", upstream.Name)
 	d.Set("vhost", upstream.Vhost)
 	d.Set("component", upstream.Component)
+	// Not known to the server, so imported upstreams and upstreams created by
+	// earlier versions fall back to the default instead of showing a change.
+	d.Set("wait_for_link", d.Get("wait_for_link"))
 
 	var uri string
 	if len(upstream.Definition.Uri) > 0 {
 		uri = upstream.Definition.Uri[0]
 	}
 	defMap := map[string]interface{}{
-		"uri":             uri,
-		"prefetch_count":  upstream.Definition.PrefetchCount,
-		"reconnect_delay": upstream.Definition.ReconnectDelay,
-		"ack_mode":        upstream.Definition.AckMode,
-		"trust_user_id":   upstream.Definition.TrustUserId,
-		"exchange":        upstream.Definition.Exchange,
-		"max_hops":        upstream.Definition.MaxHops,
-		"expires":         upstream.Definition.Expires,
-		"message_ttl":     upstream.Definition.MessageTTL,
-		"queue":           upstream.Definition.Queue,
+		"uri":                   uri,
+		"prefetch_count":        upstream.Definition.PrefetchCount,
+		"reconnect_delay":       upstream.Definition.ReconnectDelay,
+		"ack_mode":              upstream.Definition.AckMode,
+		"trust_user_id":         upstream.Definition.TrustUserId,
+		"exchange":              upstream.Definition.Exchange,
+		"max_hops":              upstream.Definition.MaxHops,
+		"expires":               upstream.Definition.Expires,
+		"message_ttl":           upstream.Definition.MessageTTL,
+		"queue":                 upstream.Definition.Queue,
+		"queue_type":            upstream.Definition.QueueType,
+		"bind_nowait":           upstream.Definition.BindNowait,
+		"channel_use_mode":      upstream.Definition.ChannelUseMode,
+		"resource_cleanup_mode": upstream.Definition.ResourceCleanupMode,
+		"consumer_tag":          upstream.Definition.ConsumerTag,
 	}
 
 	defList := [1]map[string]interface{}{defMap}
@@ -176,12 +256,12 @@ func ReadFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func UpdateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateFederationUpstream(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name, vhost, _, err := parseIdWithArgs(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("definition") {
@@ -190,23 +270,29 @@ func UpdateFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 		defList := newDef.([]interface{})
 		defMap, ok := defList[0].(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("Unable to parse federation definition")
+			return diag.Errorf("Unable to parse federation definition")
 		}
 
 		if err := putFederationUpstream(rmqc, vhost, name, defMap); err != nil {
-			return err
+			return diag.FromErr(err)
+		}
+
+		if d.Get("wait_for_link").(bool) {
+			if err := waitForFederationLink(ctx, meta.(*rabbitmqClient), vhost, name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
 
-	return ReadFederationUpstream(d, meta)
+	return ReadFederationUpstream(ctx, d, meta)
 }
 
-func DeleteFederationUpstream(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteFederationUpstream(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name, vhost, _, err := parseIdWithArgs(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete federation upstream for %s", d.Id())
@@ -214,7 +300,7 @@ func DeleteFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.DeleteFederationUpstream(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: Federation upstream delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -223,14 +309,14 @@ func DeleteFederationUpstream(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ federation upstream: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ federation upstream: %s", resp.Status)
 	}
 
 	return nil
 }
 
 func putFederationUpstream(rmqc *rabbithole.Client, vhost string, name string, defMap map[string]interface{}) error {
-	definition := rabbithole.FederationDefinition{}
+	definition := federationDefinition{}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to put federation definition for %s@%s: %#v", name, vhost, defMap)
 
@@ -274,9 +360,29 @@ func putFederationUpstream(rmqc *rabbithole.Client, vhost string, name string, d
 		definition.Queue = v
 	}
 
+	if v, ok := defMap["queue_type"].(string); ok {
+		definition.QueueType = v
+	}
+
+	if v, ok := defMap["bind_nowait"].(bool); ok {
+		definition.BindNowait = v
+	}
+
+	if v, ok := defMap["channel_use_mode"].(string); ok {
+		definition.ChannelUseMode = v
+	}
+
+	if v, ok := defMap["resource_cleanup_mode"].(string); ok {
+		definition.ResourceCleanupMode = v
+	}
+
+	if v, ok := defMap["consumer_tag"].(string); ok {
+		definition.ConsumerTag = v
+	}
+
 	log.Printf("[DEBUG] RabbitMQ: Attempting to declare federation upstream for %s@%s: %#v", name, vhost, definition)
 
-	resp, err := rmqc.PutFederationUpstream(vhost, name, definition)
+	resp, err := rmqc.PutRuntimeParameter(rabbithole.FederationUpstreamComponent, vhost, name, definition)
 	log.Printf("[DEBUG] RabbitMQ: Federation upstream declare response: %#v", resp)
 	if err != nil {
 		return err
@@ -0,0 +1,223 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+)
+
+const federationUpstreamSetComponent = "federation-upstream-set"
+
+func resourceFederationUpstreamSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateFederationUpstreamSet,
+		ReadContext:   ReadFederationUpstreamSet,
+		UpdateContext: UpdateFederationUpstreamSet,
+		DeleteContext: DeleteFederationUpstreamSet,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// "federation-upstream-set"
+			"component": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// upstreams are listed in the order they are connected to
+			"upstream": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"upstream": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						// overrides the exchange name of the upstream
+						"exchange": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						// overrides the queue name of the upstream
+						"queue": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func CreateFederationUpstreamSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+
+	if err := putFederationUpstreamSet(rmqc, vhost, name, d.Get("upstream").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := fmt.Sprintf("%s@%s", name, vhost)
+	d.SetId(id)
+
+	return ReadFederationUpstreamSet(ctx, d, meta)
+}
+
+func ReadFederationUpstreamSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name, vhost, _, err := parseIdWithArgs(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	set, err := rmqc.GetRuntimeParameter(federationUpstreamSetComponent, vhost, name)
+	if err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Federation upstream set retrieved for %s: %#v", d.Id(), set)
+
+	d.Set("name", set.Name)
+	d.Set("vhost", set.Vhost)
+	d.Set("component", set.Component)
+
+	values, ok := set.Value.([]interface{})
+	if !ok {
+		return diag.Errorf("Unable to parse federation upstream set %s: %#v", d.Id(), set.Value)
+	}
+
+	upstreams := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		upstreamMap, ok := value.(map[string]interface{})
+		if !ok {
+			return diag.Errorf("Unable to parse federation upstream set %s: %#v", d.Id(), set.Value)
+		}
+
+		upstream := map[string]interface{}{}
+		for _, key := range []string{"upstream", "exchange", "queue"} {
+			if v, ok := upstreamMap[key].(string); ok {
+				upstream[key] = v
+			}
+		}
+
+		upstreams = append(upstreams, upstream)
+	}
+
+	d.Set("upstream", upstreams)
+
+	return nil
+}
+
+func UpdateFederationUpstreamSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name, vhost, _, err := parseIdWithArgs(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("upstream") {
+		if err := putFederationUpstreamSet(rmqc, vhost, name, d.Get("upstream").([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadFederationUpstreamSet(ctx, d, meta)
+}
+
+func DeleteFederationUpstreamSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name, vhost, _, err := parseIdWithArgs(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete federation upstream set for %s", d.Id())
+
+	resp, err := rmqc.DeleteRuntimeParameter(federationUpstreamSetComponent, vhost, name)
+	log.Printf("[DEBUG] RabbitMQ: Federation upstream set delete response: %#v", resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if resp.StatusCode == 404 {
+		// the upstream set was already deleted
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return diag.Errorf("Error deleting RabbitMQ federation upstream set: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func putFederationUpstreamSet(rmqc *rabbithole.Client, vhost string, name string, upstreamList []interface{}) error {
+	upstreams := make([]map[string]interface{}, 0, len(upstreamList))
+
+	for _, v := range upstreamList {
+		upstreamMap, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Unable to parse federation upstream set")
+		}
+
+		upstream := map[string]interface{}{
+			"upstream": upstreamMap["upstream"],
+		}
+
+		// empty overrides are left out so that the upstream defaults apply
+		for _, key := range []string{"exchange", "queue"} {
+			if value, ok := upstreamMap[key].(string); ok && value != "" {
+				upstream[key] = value
+			}
+		}
+
+		upstreams = append(upstreams, upstream)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare federation upstream set for %s@%s: %#v", name, vhost, upstreams)
+
+	resp, err := rmqc.PutRuntimeParameter(federationUpstreamSetComponent, vhost, name, upstreams)
+	log.Printf("[DEBUG] RabbitMQ: Federation upstream set declare response: %#v", resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error declaring RabbitMQ federation upstream set: %s", resp.Status)
+	}
+
+	return nil
+}
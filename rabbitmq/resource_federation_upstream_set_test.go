@@ -0,0 +1,124 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccFederationUpstreamSet(t *testing.T) {
+	resourceName := "rabbitmq_federation_upstream_set.foo"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccFederationUpstreamSetCheckDestroy("foo", "test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFederationUpstreamSet_create(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFederationUpstreamSetCheck(resourceName, 1),
+					resource.TestCheckResourceAttr(resourceName, "component", "federation-upstream-set"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.upstream", "a"),
+				)},
+			{
+				Config: testAccFederationUpstreamSet_update(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFederationUpstreamSetCheck(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "upstream.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.upstream", "b"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.0.exchange", "orders"),
+					resource.TestCheckResourceAttr(resourceName, "upstream.1.upstream", "a"),
+				)},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccFederationUpstreamSetCheck(rn string, upstreams int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("federation upstream set id not set")
+		}
+
+		name, vhost, _, err := parseIdWithArgs(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		set, err := rmqc.GetRuntimeParameter(federationUpstreamSetComponent, vhost, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving federation upstream set: %s", err)
+		}
+
+		if values, ok := set.Value.([]interface{}); !ok || len(values) != upstreams {
+			return fmt.Errorf("Federation upstream set %s is %#v, expected %d upstreams", rs.Primary.ID, set.Value, upstreams)
+		}
+
+		return nil
+	}
+}
+
+func testAccFederationUpstreamSetCheckDestroy(name string, vhost string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		sets, err := rmqc.ListRuntimeParametersFor(federationUpstreamSetComponent)
+		if err != nil {
+			return fmt.Errorf("Error retrieving federation upstream sets: %s", err)
+		}
+
+		for _, set := range sets {
+			if set.Name == name && set.Vhost == vhost {
+				return fmt.Errorf("Federation upstream set %s@%s still exists", name, vhost)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccFederationUpstreamSet_create() string {
+	return testAccFederationUpstream_baseConfig() + `
+resource "rabbitmq_federation_upstream_set" "foo" {
+		name = "foo"
+		vhost = rabbitmq_permissions.guest.vhost
+
+		upstream {
+				upstream = "a"
+		}
+}
+`
+}
+
+func testAccFederationUpstreamSet_update() string {
+	return testAccFederationUpstream_baseConfig() + `
+resource "rabbitmq_federation_upstream_set" "foo" {
+		name = "foo"
+		vhost = rabbitmq_permissions.guest.vhost
+
+		upstream {
+				upstream = "b"
+				exchange = "orders"
+		}
+
+		upstream {
+				upstream = "a"
+		}
+}
+`
+}
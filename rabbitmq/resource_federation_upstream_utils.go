@@ -0,0 +1,331 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	federationLinkStatusStarting = "starting"
+	federationLinkStatusRunning  = "running"
+	federationLinkStatusError    = "error"
+
+	// not reported by RabbitMQ, the upstream has no link while no policy applies it
+	federationLinkStatusMissing = "missing"
+)
+
+const (
+	federationModeExchange = "exchange"
+	federationModeQueue    = "queue"
+)
+
+/*
+The definition settings applicable to a single mode, with the values
+they take when unset.
+*/
+var federationModeSettings = map[string]map[string]interface{}{
+
+	federationModeExchange: {
+
+		"exchange":              "",
+		"max_hops":              1,
+		"expires":               0,
+		"message_ttl":           0,
+		"queue_type":            "",
+		"bind_nowait":           false,
+		"channel_use_mode":      "",
+		"resource_cleanup_mode": "",
+	},
+
+	federationModeQueue: {
+
+		"queue":        "",
+		"consumer_tag": "",
+	},
+}
+
+// Upstream definition with the settings that rabbit-hole leaves out.
+type federationDefinition struct {
+	rabbithole.FederationDefinition
+
+	ConsumerTag string `json:"consumer-tag,omitempty"`
+
+	QueueType string `json:"queue-type,omitempty"`
+
+	BindNowait bool `json:"bind-nowait,omitempty"`
+
+	ChannelUseMode string `json:"channel-use-mode,omitempty"`
+
+	ResourceCleanupMode string `json:"resource-cleanup-mode,omitempty"`
+}
+
+type federationUpstream struct {
+	Name string `json:"name"`
+
+	Vhost string `json:"vhost"`
+
+	Component string `json:"component"`
+
+	Definition federationDefinition `json:"value"`
+}
+
+func getFederationUpstream(ctx context.Context, rmqc *rabbitmqClient, vhost string, name string) (*federationUpstream, error) {
+
+	var upstream federationUpstream
+
+	path := fmt.Sprintf("parameters/%s/%s/%s", rabbithole.FederationUpstreamComponent, url.PathEscape(vhost), url.PathEscape(name))
+
+	if err := rmqc.executeJSONRequest(ctx, http.MethodGet, path, nil, &upstream); err != nil {
+
+		return nil, err
+	}
+
+	return &upstream, nil
+}
+
+// Checks that the definition only sets the settings applicable to the mode.
+func validateFederationUpstreamMode(mode string, defMap map[string]interface{}) error {
+
+	for other, settings := range federationModeSettings {
+
+		if other == mode {
+
+			continue
+		}
+
+		keys := make([]string, 0, len(settings))
+
+		for key := range settings {
+
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+
+			if value, ok := defMap[key]; ok && value != settings[key] {
+
+				return fmt.Errorf("definition.0.%s is only applicable to federated %ss, but mode is %s", key, other, mode)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validates the definition against the mode when planning.
+func customizeFederationUpstreamDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+	// Unknown values are checked again once they are known.
+	if !d.NewValueKnown("mode") || !d.NewValueKnown("definition") {
+
+		return nil
+	}
+
+	mode := d.Get("mode").(string)
+	defList := d.Get("definition").([]interface{})
+
+	if mode == "" || len(defList) == 0 || defList[0] == nil {
+
+		return nil
+	}
+
+	return validateFederationUpstreamMode(mode, defList[0].(map[string]interface{}))
+}
+
+/*
+Status of a federation link as reported by /api/federation-links. Links
+federating exchanges report the local and upstream exchanges, while links
+federating queues report the local and upstream queues.
+*/
+type federationLink struct {
+	Id string `json:"id"`
+
+	Vhost string `json:"vhost"`
+
+	Upstream string `json:"upstream"`
+
+	Type string `json:"type"`
+
+	Status string `json:"status"`
+
+	Node string `json:"node"`
+
+	Timestamp string `json:"timestamp"`
+
+	Uri string `json:"uri"`
+
+	LocalConnection string `json:"local_connection"`
+
+	Error string `json:"error"`
+
+	Exchange string `json:"exchange"`
+
+	UpstreamExchange string `json:"upstream_exchange"`
+
+	Queue string `json:"queue"`
+
+	UpstreamQueue string `json:"upstream_queue"`
+}
+
+// Returns the name of the federated exchange or queue.
+func (link federationLink) localName() string {
+
+	if link.Type == "queue" {
+
+		return link.Queue
+	}
+
+	return link.Exchange
+}
+
+// Returns the name of the exchange or queue the link consumes from.
+func (link federationLink) upstreamName() string {
+
+	if link.Type == "queue" {
+
+		return link.UpstreamQueue
+	}
+
+	return link.UpstreamExchange
+}
+
+/*
+Returns the federation links of the vhost, or of all vhosts when vhost is
+empty, that use the upstream, or any upstream when upstream is empty.
+*/
+func listFederationLinks(ctx context.Context, rmqc *rabbitmqClient, vhost string, upstream string) ([]federationLink, error) {
+
+	path := "federation-links"
+
+	if vhost != "" {
+
+		path += "/" + url.PathEscape(vhost)
+	}
+
+	var links []federationLink
+
+	if err := rmqc.executeJSONRequest(ctx, http.MethodGet, path, nil, &links); err != nil {
+
+		return nil, err
+	}
+
+	if upstream == "" {
+
+		return links, nil
+	}
+
+	results := make([]federationLink, 0, len(links))
+
+	for _, link := range links {
+
+		if link.Upstream == upstream {
+
+			results = append(results, link)
+		}
+	}
+
+	return results, nil
+}
+
+/*
+Waits until all the links of the upstream run, failing with the error
+reported by the first failed link. Links only exist once a policy applies
+the upstream, so the wait times out when no policy does. The links must be
+seen running twice in a row, since right after an update they may still
+report the status of the previous definition, so they are polled every
+second rather than with a growing delay.
+*/
+func waitForFederationLink(ctx context.Context, rmqc *rabbitmqClient, vhost string, upstream string, timeout time.Duration) error {
+
+	var last []federationLink
+
+	stateConf := &retry.StateChangeConf{
+
+		Pending:                   []string{federationLinkStatusMissing, federationLinkStatusStarting},
+		Target:                    []string{federationLinkStatusRunning},
+		Timeout:                   timeout,
+		PollInterval:              time.Second,
+		ContinuousTargetOccurence: 2,
+
+		Refresh: func() (interface{}, string, error) {
+
+			links, err := listFederationLinks(ctx, rmqc, vhost, upstream)
+
+			if err != nil {
+
+				return nil, "", err
+			}
+
+			last = links
+
+			if len(links) == 0 {
+
+				log.Printf("[DEBUG] RabbitMQ: Federation upstream %s@%s has no link yet", upstream, vhost)
+
+				return links, federationLinkStatusMissing, nil
+			}
+
+			for _, link := range links {
+
+				log.Printf("[DEBUG] RabbitMQ: Federation link %s of upstream %s@%s is %s", link.localName(), upstream, vhost, link.Status)
+
+				if link.Status == federationLinkStatusError {
+
+					return links, link.Status, fmt.Errorf("federation link %s of upstream %s@%s failed: %s", link.localName(), upstream, vhost, link.Error)
+				}
+			}
+
+			for _, link := range links {
+
+				if link.Status != federationLinkStatusRunning {
+
+					return links, federationLinkStatusStarting, nil
+				}
+			}
+
+			return links, federationLinkStatusRunning, nil
+		},
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	var timeoutErr *retry.TimeoutError
+
+	if errors.As(err, &timeoutErr) && len(last) == 0 {
+
+		return fmt.Errorf("federation upstream %s@%s has no links to wait for, a policy applying it must exist before it: %s", upstream, vhost, err)
+	}
+
+	if errors.As(err, &timeoutErr) {
+
+		statuses := make([]string, 0, len(last))
+
+		for _, link := range last {
+
+			statuses = append(statuses, fmt.Sprintf("%s is %s", link.localName(), link.Status))
+		}
+
+		return fmt.Errorf("federation upstream %s@%s is not running: %s (%s)", upstream, vhost, err, strings.Join(statuses, ", "))
+	}
+
+	if err != nil {
+
+		return fmt.Errorf("federation upstream %s@%s is not running: %s", upstream, vhost, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGlobalParameter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateGlobalParameter,
+		UpdateContext: UpdateGlobalParameter,
+		ReadContext:   ReadGlobalParameter,
+		DeleteContext: DeleteGlobalParameter,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func CreateGlobalParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name := d.Get("name").(string)
+
+	if err := putGlobalParameter(rmqc, name, d.Get("value").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+
+	return ReadGlobalParameter(ctx, d, meta)
+}
+
+func ReadGlobalParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	parameter, err := rmqc.GetGlobalParameter(d.Id())
+	if err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Global parameter retrieved for %s: %#v", d.Id(), parameter)
+
+	value, err := json.Marshal(parameter.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", parameter.Name)
+	d.Set("value", string(value))
+
+	return nil
+}
+
+func UpdateGlobalParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	if d.HasChange("value") {
+		if err := putGlobalParameter(rmqc, d.Id(), d.Get("value").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadGlobalParameter(ctx, d, meta)
+}
+
+func DeleteGlobalParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete global parameter %s", d.Id())
+
+	resp, err := rmqc.DeleteGlobalParameter(d.Id())
+	log.Printf("[DEBUG] RabbitMQ: Global parameter delete response: %#v", resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if resp.StatusCode == 404 {
+		// the global parameter was already deleted
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return diag.Errorf("Error deleting RabbitMQ global parameter: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func putGlobalParameter(rmqc *rabbithole.Client, name string, rawValue string) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+		return fmt.Errorf("Unable to parse the value of global parameter %s: %w", name, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to set global parameter %s: %#v", name, value)
+
+	resp, err := rmqc.PutGlobalParameter(name, value)
+	log.Printf("[DEBUG] RabbitMQ: Global parameter set response: %#v", resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error setting RabbitMQ global parameter: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGlobalParameter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGlobalParameterCheckDestroy("terraform-test"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlobalParameterConfig_basic,
+				Check: testAccGlobalParameterCheck(
+					"rabbitmq_global_parameter.test", `{"environment": "test", "replicas": 3}`,
+				),
+			},
+			{
+				Config: testAccGlobalParameterConfig_update,
+				Check: testAccGlobalParameterCheck(
+					"rabbitmq_global_parameter.test", `{"environment": "test", "replicas": 5}`,
+				),
+			},
+		},
+	})
+}
+
+func testAccGlobalParameterCheck(rn string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("global parameter id not set")
+		}
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		parameter, err := rmqc.GetGlobalParameter(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error retrieving global parameter: %s", err)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal([]byte(expected), &value); err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(parameter.Value, value) {
+			return fmt.Errorf("Global parameter %s is %#v, expected %#v", rs.Primary.ID, parameter.Value, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccGlobalParameterCheckDestroy(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		parameters, err := rmqc.ListGlobalParameters()
+		if err != nil {
+			return fmt.Errorf("Error retrieving global parameters: %s", err)
+		}
+
+		for _, parameter := range parameters {
+			if parameter.Name == name {
+				return fmt.Errorf("Global parameter %s still exists", name)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccGlobalParameterConfig_basic = `
+resource "rabbitmq_global_parameter" "test" {
+    name = "terraform-test"
+    value = jsonencode({
+        environment = "test"
+        replicas = 3
+    })
+}`
+
+const testAccGlobalParameterConfig_update = `
+resource "rabbitmq_global_parameter" "test" {
+    name = "terraform-test"
+    value = jsonencode({
+        environment = "test"
+        replicas = 5
+    })
+}`
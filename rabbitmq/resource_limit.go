@@ -1,30 +1,38 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
 func resourceLimit() *schema.Resource {
 
 	return &schema.Resource{
 
-		Create: CreateLimit,
-		Read:   ReadLimit,
-		Update: CreateLimit,
-		Delete: DeleteLimit,
+		CreateContext: CreateLimit,
+		ReadContext:   ReadLimit,
+		UpdateContext: CreateLimit,
+		DeleteContext: DeleteLimit,
 
 		Importer: &schema.ResourceImporter{
 
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 
 			"scope": {
@@ -61,7 +69,7 @@ func resourceLimit() *schema.Resource {
 	}
 }
 
-func CreateLimit(d *schema.ResourceData, meta interface{}) error {
+func CreateLimit(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	scope := d.Get("scope").(string)
 	limit := d.Get("limit").(string)
@@ -70,7 +78,7 @@ func CreateLimit(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to create %s limit for %s with value %d", limit, alias, value)
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	var (
 		resp *http.Response
@@ -89,26 +97,26 @@ func CreateLimit(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s@%s@%s", scope, limit, alias))
 
-	return ReadLimit(d, meta)
+	return ReadLimit(ctx, d, meta)
 }
 
-func ReadLimit(d *schema.ResourceData, meta interface{}) error {
+func ReadLimit(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	scope, limit, alias, err := parseLimitID(d.Id())
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to retrieve limits for %s", alias)
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	var limits map[string]int
 
@@ -122,7 +130,7 @@ func ReadLimit(d *schema.ResourceData, meta interface{}) error {
 
 	if err != nil {
 
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Limit %s retrieved for %s", limit, alias)
@@ -143,18 +151,18 @@ func ReadLimit(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func DeleteLimit(d *schema.ResourceData, meta interface{}) error {
+func DeleteLimit(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	scope, limit, alias, err := parseLimitID(d.Id())
 
 	if err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete limit %s for %s", limit, alias)
 
-	rmqc := meta.(*rabbithole.Client)
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	var resp *http.Response
 
@@ -168,5 +176,5 @@ func DeleteLimit(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] RabbitMQ: limit deletion response: %#v", resp)
 
-	return err
+	return diag.FromErr(err)
 }
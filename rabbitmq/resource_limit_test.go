@@ -6,8 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
 const testLimitUser = `
@@ -120,7 +118,7 @@ func testLimit(s *terraform.State, resource string, predicate ValidateDictionary
 		return err
 	}
 
-	rmqc := testAccProvider.Meta().(*rabbithole.Client)
+	rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 	var limits map[string]int
 
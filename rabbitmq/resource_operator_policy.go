@@ -1,25 +1,36 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceOperatorPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateOperatorPolicy,
-		Update: UpdateOperatorPolicy,
-		Read:   ReadOperatorPolicy,
-		Delete: DeleteOperatorPolicy,
+		CreateContext: CreateOperatorPolicy,
+		UpdateContext: UpdateOperatorPolicy,
+		ReadContext:   ReadOperatorPolicy,
+		DeleteContext: DeleteOperatorPolicy,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		CustomizeDiff: customizePolicyDiff(true),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -55,9 +66,19 @@ func resourceOperatorPolicy() *schema.Resource {
 							Required: true,
 						},
 
+						// String values only, numbers are detected.
 						"definition": {
-							Type:     schema.TypeMap,
-							Required: true,
+							Type:         schema.TypeMap,
+							Optional:     true,
+							ExactlyOneOf: []string{"policy.0.definition", "policy.0.definition_json"},
+						},
+
+						"definition_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+							ExactlyOneOf:     []string{"policy.0.definition", "policy.0.definition_json"},
 						},
 					},
 				},
@@ -66,8 +87,8 @@ func resourceOperatorPolicy() *schema.Resource {
 	}
 }
 
-func CreateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateOperatorPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -75,24 +96,24 @@ func CreateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 
 	operatorPolicyMap, ok := operatorPolicyList[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("Unable to parse operator policy")
+		return diag.Errorf("Unable to parse operator policy")
 	}
 
 	if err := putOperatorPolicy(rmqc, vhost, name, operatorPolicyMap); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s@%s", name, vhost))
 
-	return ReadOperatorPolicy(d, meta)
+	return ReadOperatorPolicy(ctx, d, meta)
 }
 
-func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadOperatorPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	operatorPolicyId := strings.Split(d.Id(), "@")
 	if len(operatorPolicyId) < 2 {
-		return fmt.Errorf("Unable to determine operator policy ID")
+		return diag.Errorf("Unable to determine operator policy ID")
 	}
 
 	name := operatorPolicyId[0]
@@ -100,7 +121,7 @@ func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 
 	operatorPolicy, err := rmqc.GetOperatorPolicy(vhost, name)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: OperatorPolicy retrieved for %s: %#v", d.Id(), operatorPolicy)
@@ -109,41 +130,19 @@ func ReadOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 	d.Set("vhost", operatorPolicy.Vhost)
 
 	setOperatorPolicy := make([]map[string]interface{}, 1)
-	p := make(map[string]interface{})
-	p["pattern"] = operatorPolicy.Pattern
-	p["priority"] = operatorPolicy.Priority
-	p["apply_to"] = operatorPolicy.ApplyTo
-
-	operatorPolicyDefinition := make(map[string]interface{})
-	for key, value := range operatorPolicy.Definition {
-		switch v := value.(type) {
-		case float64:
-			value = strconv.FormatFloat(v, 'f', -1, 64)
-		case []interface{}:
-			var nodes []string
-			for _, node := range v {
-				if n, ok := node.(string); ok {
-					nodes = append(nodes, n)
-				}
-			}
-			value = strings.Join(nodes, ",")
-		}
-		operatorPolicyDefinition[key] = value
-	}
-	p["definition"] = operatorPolicyDefinition
-	setOperatorPolicy[0] = p
+	setOperatorPolicy[0] = flattenPolicy(d, operatorPolicy.Pattern, operatorPolicy.Priority, operatorPolicy.ApplyTo, operatorPolicy.Definition)
 
 	d.Set("policy", setOperatorPolicy)
 
 	return nil
 }
 
-func UpdateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateOperatorPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	operatorPolicyId := strings.Split(d.Id(), "@")
 	if len(operatorPolicyId) < 2 {
-		return fmt.Errorf("Unable to determine operator policy ID")
+		return diag.Errorf("Unable to determine operator policy ID")
 	}
 
 	name := operatorPolicyId[0]
@@ -155,23 +154,23 @@ func UpdateOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 		operatorPolicyList := newOperatorPolicy.([]interface{})
 		operatorPolicyMap, ok := operatorPolicyList[0].(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("Unable to parse operator policy")
+			return diag.Errorf("Unable to parse operator policy")
 		}
 
 		if err := putOperatorPolicy(rmqc, vhost, name, operatorPolicyMap); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return ReadOperatorPolicy(d, meta)
+	return ReadOperatorPolicy(ctx, d, meta)
 }
 
-func DeleteOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteOperatorPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	operatorPolicyId := strings.Split(d.Id(), "@")
 	if len(operatorPolicyId) < 2 {
-		return fmt.Errorf("Unable to determine operator policy ID")
+		return diag.Errorf("Unable to determine operator policy ID")
 	}
 
 	name := operatorPolicyId[0]
@@ -182,7 +181,7 @@ func DeleteOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.DeleteOperatorPolicy(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: OperatorPolicy delete response: %#v", resp)
 	if err != nil {
-		return fmt.Errorf("could not delete operator policy: %w", err)
+		return diag.FromErr(fmt.Errorf("could not delete operator policy: %w", err))
 	}
 
 	if resp.StatusCode == 404 {
@@ -191,7 +190,7 @@ func DeleteOperatorPolicy(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ operator policy: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ operator policy: %s", resp.Status)
 	}
 
 	return nil
@@ -214,18 +213,11 @@ func putOperatorPolicy(rmqc *rabbithole.Client, vhost string, name string, opera
 		operatorPolicy.ApplyTo = v
 	}
 
-	if v, ok := operatorPolicyMap["definition"].(map[string]interface{}); ok {
-		// special case for integers
-		for key, val := range v {
-			if x, ok := val.(string); ok {
-				if x, err := strconv.ParseInt(x, 10, 64); err == nil {
-					v[key] = x
-				}
-			}
-		}
-
-		operatorPolicy.Definition = v
+	definition, err := policyDefinition(operatorPolicyMap)
+	if err != nil {
+		return err
 	}
+	operatorPolicy.Definition = definition
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to declare operator policy for %s@%s: %#v", name, vhost, operatorPolicy)
 
@@ -1,6 +1,7 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -45,6 +46,38 @@ func TestAccOperatorPolicy(t *testing.T) {
 	})
 }
 
+func TestOperatorPolicyDefinitionValidation(t *testing.T) {
+	for definition, valid := range map[string]bool{
+		`{"max-length": 10000, "message-ttl": 5000}`: true,
+		`{"delivery-limit": 10, "expires": 60000}`:   true,
+		`{"federation-upstream-set": "all"}`:         false,
+		`{"max-length-bytes": "10"}`:                 false,
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":  "test",
+			"vhost": "test",
+			"policy": []interface{}{map[string]interface{}{
+				"pattern":         ".*",
+				"priority":        0,
+				"apply_to":        "queues",
+				"definition_json": definition,
+			}},
+		})
+
+		_, err := resourceOperatorPolicy().Diff(context.Background(), nil, config, nil)
+		if diags := resourceOperatorPolicy().Validate(config); diags.HasError() {
+			err = fmt.Errorf("%v", diags)
+		}
+
+		if valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", definition, err)
+		}
+		if !valid && err == nil {
+			t.Errorf("%s: expected an error", definition)
+		}
+	}
+}
+
 func testAccOperatorPolicyCheck(rn string, operatorPolicy *rabbithole.OperatorPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -56,7 +89,7 @@ func testAccOperatorPolicyCheck(rn string, operatorPolicy *rabbithole.OperatorPo
 			return fmt.Errorf("operator policy id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		operatorPolicyParts := strings.Split(rs.Primary.ID, "@")
 
 		operatorPolicies, err := rmqc.ListOperatorPolicies()
@@ -77,7 +110,7 @@ func testAccOperatorPolicyCheck(rn string, operatorPolicy *rabbithole.OperatorPo
 
 func testAccOperatorPolicyCheckDestroy(operatorPolicy *rabbithole.OperatorPolicy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 		operatorPolicies, err := rmqc.ListOperatorPolicies()
 		if err != nil {
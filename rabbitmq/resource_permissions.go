@@ -1,24 +1,32 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePermissions() *schema.Resource {
 	return &schema.Resource{
-		Create: CreatePermissions,
-		Update: UpdatePermissions,
-		Read:   ReadPermissions,
-		Delete: DeletePermissions,
+		CreateContext: CreatePermissions,
+		UpdateContext: UpdatePermissions,
+		ReadContext:   ReadPermissions,
+		DeleteContext: DeletePermissions,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"user": {
@@ -61,8 +69,8 @@ func resourcePermissions() *schema.Resource {
 	}
 }
 
-func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreatePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user := d.Get("user").(string)
 	vhost := d.Get("vhost").(string)
@@ -74,21 +82,21 @@ func CreatePermissions(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err := setPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	id := fmt.Sprintf("%s@%s", user, vhost)
 	d.SetId(id)
 
-	return ReadPermissions(d, meta)
+	return ReadPermissions(ctx, d, meta)
 }
 
-func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	permissionId := strings.Split(d.Id(), "@")
 	if len(permissionId) < 2 {
-		return fmt.Errorf("Unable to determine Permission ID")
+		return diag.Errorf("Unable to determine Permission ID")
 	}
 
 	user := permissionId[0]
@@ -96,7 +104,7 @@ func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
 
 	userPerms, err := rmqc.GetPermissionsIn(vhost, user)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Permission retrieved for %s: %#v", d.Id(), userPerms)
@@ -115,12 +123,12 @@ func ReadPermissions(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func UpdatePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdatePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, vhost, err := parseID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("permissions") {
@@ -129,23 +137,23 @@ func UpdatePermissions(d *schema.ResourceData, meta interface{}) error {
 		newPermsList := newPerms.([]interface{})
 		permsMap, ok := newPermsList[0].(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("Unable to parse permissions")
+			return diag.Errorf("Unable to parse permissions")
 		}
 
 		if err := setPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return ReadPermissions(d, meta)
+	return ReadPermissions(ctx, d, meta)
 }
 
-func DeletePermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeletePermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, vhost, err := parseID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete permission for %s", d.Id())
@@ -153,7 +161,7 @@ func DeletePermissions(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.ClearPermissionsIn(vhost, user)
 	log.Printf("[DEBUG] RabbitMQ: Permission delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -162,7 +170,7 @@ func DeletePermissions(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ permission: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ permission: %s", resp.Status)
 	}
 
 	return nil
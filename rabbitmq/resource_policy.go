@@ -1,25 +1,36 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourcePolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: CreatePolicy,
-		Update: UpdatePolicy,
-		Read:   ReadPolicy,
-		Delete: DeletePolicy,
+		CreateContext: CreatePolicy,
+		UpdateContext: UpdatePolicy,
+		ReadContext:   ReadPolicy,
+		DeleteContext: DeletePolicy,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		CustomizeDiff: customizePolicyDiff(false),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -55,9 +66,19 @@ func resourcePolicy() *schema.Resource {
 							Required: true,
 						},
 
+						// String values only, numbers are detected.
 						"definition": {
-							Type:     schema.TypeMap,
-							Required: true,
+							Type:         schema.TypeMap,
+							Optional:     true,
+							ExactlyOneOf: []string{"policy.0.definition", "policy.0.definition_json"},
+						},
+
+						"definition_json": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+							ExactlyOneOf:     []string{"policy.0.definition", "policy.0.definition_json"},
 						},
 					},
 				},
@@ -66,8 +87,8 @@ func resourcePolicy() *schema.Resource {
 	}
 }
 
-func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreatePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -75,25 +96,25 @@ func CreatePolicy(d *schema.ResourceData, meta interface{}) error {
 
 	policyMap, ok := policyList[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("Unable to parse policy")
+		return diag.Errorf("Unable to parse policy")
 	}
 
 	if err := putPolicy(rmqc, vhost, name, policyMap); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	id := fmt.Sprintf("%s@%s", name, vhost)
 	d.SetId(id)
 
-	return ReadPolicy(d, meta)
+	return ReadPolicy(ctx, d, meta)
 }
 
-func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	policyId := strings.Split(d.Id(), "@")
 	if len(policyId) < 2 {
-		return fmt.Errorf("Unable to determine policy ID")
+		return diag.Errorf("Unable to determine policy ID")
 	}
 
 	name := policyId[0]
@@ -101,7 +122,7 @@ func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
 
 	policy, err := rmqc.GetPolicy(vhost, name)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Policy retrieved for %s: %#v", d.Id(), policy)
@@ -110,41 +131,19 @@ func ReadPolicy(d *schema.ResourceData, meta interface{}) error {
 	d.Set("vhost", policy.Vhost)
 
 	setPolicy := make([]map[string]interface{}, 1)
-	p := make(map[string]interface{})
-	p["pattern"] = policy.Pattern
-	p["priority"] = policy.Priority
-	p["apply_to"] = policy.ApplyTo
-
-	policyDefinition := make(map[string]interface{})
-	for key, value := range policy.Definition {
-		switch v := value.(type) {
-		case float64:
-			value = strconv.FormatFloat(v, 'f', -1, 64)
-		case []interface{}:
-			var nodes []string
-			for _, node := range v {
-				if n, ok := node.(string); ok {
-					nodes = append(nodes, n)
-				}
-			}
-			value = strings.Join(nodes, ",")
-		}
-		policyDefinition[key] = value
-	}
-	p["definition"] = policyDefinition
-	setPolicy[0] = p
+	setPolicy[0] = flattenPolicy(d, policy.Pattern, policy.Priority, policy.ApplyTo, policy.Definition)
 
 	d.Set("policy", setPolicy)
 
 	return nil
 }
 
-func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdatePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	policyId := strings.Split(d.Id(), "@")
 	if len(policyId) < 2 {
-		return fmt.Errorf("Unable to determine policy ID")
+		return diag.Errorf("Unable to determine policy ID")
 	}
 
 	name := policyId[0]
@@ -156,23 +155,23 @@ func UpdatePolicy(d *schema.ResourceData, meta interface{}) error {
 		policyList := newPolicy.([]interface{})
 		policyMap, ok := policyList[0].(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("Unable to parse policy")
+			return diag.Errorf("Unable to parse policy")
 		}
 
 		if err := putPolicy(rmqc, vhost, name, policyMap); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return ReadPolicy(d, meta)
+	return ReadPolicy(ctx, d, meta)
 }
 
-func DeletePolicy(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeletePolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	policyId := strings.Split(d.Id(), "@")
 	if len(policyId) < 2 {
-		return fmt.Errorf("Unable to determine policy ID")
+		return diag.Errorf("Unable to determine policy ID")
 	}
 
 	name := policyId[0]
@@ -183,7 +182,7 @@ func DeletePolicy(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.DeletePolicy(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: Policy delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -192,7 +191,7 @@ func DeletePolicy(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ policy: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ policy: %s", resp.Status)
 	}
 
 	return nil
@@ -215,27 +214,11 @@ func putPolicy(rmqc *rabbithole.Client, vhost string, name string, policyMap map
 		policy.ApplyTo = v
 	}
 
-	if v, ok := policyMap["definition"].(map[string]interface{}); ok {
-		// special case for ha-mode = nodes
-		if x, ok := v["ha-mode"]; ok && x == "nodes" {
-			var nodes rabbithole.NodeNames
-			if _, ok := v["ha-params"].(string); ok {
-				nodes = strings.Split(v["ha-params"].(string), ",")
-				v["ha-params"] = nodes
-			}
-		}
-
-		// special case for integers
-		for key, val := range v {
-			if x, ok := val.(string); ok {
-				if x, err := strconv.ParseInt(x, 10, 64); err == nil {
-					v[key] = x
-				}
-			}
-		}
-
-		policy.Definition = v
+	definition, err := policyDefinition(policyMap)
+	if err != nil {
+		return err
 	}
+	policy.Definition = definition
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to declare policy for %s@%s: %#v", name, vhost, policy)
 
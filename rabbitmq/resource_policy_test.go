@@ -1,6 +1,7 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -34,6 +35,104 @@ func TestAccPolicy(t *testing.T) {
 	})
 }
 
+func TestAccPolicy_json(t *testing.T) {
+	var policy rabbithole.Policy
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccPolicyCheckDestroy(&policy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig_json,
+				Check: resource.ComposeTestCheckFunc(
+					testAccPolicyCheck("rabbitmq_policy.test", &policy),
+					testAccPolicyCheckDefinition("rabbitmq_policy.test", "federation-upstream-set", "all"),
+					testAccPolicyCheckDefinition("rabbitmq_policy.test", "max-length", float64(10000)),
+				),
+			},
+		},
+	})
+}
+
+func TestPolicyDefinitionValidation(t *testing.T) {
+	for name, test := range map[string]struct {
+		policy map[string]interface{}
+		valid  bool
+	}{
+		"string definition": {
+			policy: map[string]interface{}{"definition": map[string]interface{}{"max-length": "10000", "queue-mode": "lazy", "custom-key": "x"}},
+			valid:  true,
+		},
+		"json definition": {
+			policy: map[string]interface{}{"definition_json": `{"message-ttl": 5000, "overflow": "reject-publish", "ha-mode": "nodes", "ha-params": ["a", "b"]}`},
+			valid:  true,
+		},
+		"both definitions": {
+			policy: map[string]interface{}{"definition": map[string]interface{}{"max-length": "1"}, "definition_json": `{"max-length": 1}`},
+		},
+		"string integer in json": {
+			policy: map[string]interface{}{"definition_json": `{"max-length": "10000"}`},
+		},
+		"float integer": {
+			policy: map[string]interface{}{"definition_json": `{"max-length": 1.5}`},
+		},
+		"negative integer": {
+			policy: map[string]interface{}{"definition": map[string]interface{}{"message-ttl": "-1"}},
+		},
+		"unknown queue mode": {
+			policy: map[string]interface{}{"definition": map[string]interface{}{"queue-mode": "lazzy"}},
+		},
+		"exactly without a number": {
+			policy: map[string]interface{}{"definition": map[string]interface{}{"ha-mode": "exactly", "ha-params": "a,b"}},
+		},
+	} {
+		test.policy["pattern"] = ".*"
+		test.policy["priority"] = 0
+		test.policy["apply_to"] = "all"
+
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":   "test",
+			"vhost":  "test",
+			"policy": []interface{}{test.policy},
+		})
+
+		_, err := resourcePolicy().Diff(context.Background(), nil, config, nil)
+		if diags := resourcePolicy().Validate(config); diags.HasError() {
+			err = fmt.Errorf("%v", diags)
+		}
+
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func testAccPolicyCheckDefinition(rn string, key string, value interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		policyParts := strings.Split(rs.Primary.ID, "@")
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		policy, err := rmqc.GetPolicy(policyParts[1], policyParts[0])
+		if err != nil {
+			return fmt.Errorf("Error retrieving policy: %s", err)
+		}
+
+		if policy.Definition[key] != value {
+			return fmt.Errorf("Expected %s to be %#v, got %#v", key, value, policy.Definition[key])
+		}
+
+		return nil
+	}
+}
+
 func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -45,7 +144,7 @@ func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheck
 			return fmt.Errorf("policy id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		policyParts := strings.Split(rs.Primary.ID, "@")
 
 		policies, err := rmqc.ListPolicies()
@@ -66,7 +165,7 @@ func testAccPolicyCheck(rn string, policy *rabbithole.Policy) resource.TestCheck
 
 func testAccPolicyCheckDestroy(policy *rabbithole.Policy) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 		policies, err := rmqc.ListPolicies()
 		if err != nil {
@@ -140,3 +239,32 @@ resource "rabbitmq_policy" "test" {
         }
     }
 }`
+
+const testAccPolicyConfig_json = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_policy" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    policy {
+        pattern = ".*"
+        priority = 0
+        apply_to = "queues"
+        definition_json = jsonencode({
+            "federation-upstream-set" = "all"
+            "max-length" = 10000
+        })
+    }
+}`
@@ -0,0 +1,259 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	policyKeyInteger = "integer"
+	policyKeyString  = "string"
+
+	// ha-params is a number of replicas or a list of nodes depending on ha-mode
+	policyKeyHaParams = "ha-params"
+)
+
+// Describes a policy key known by RabbitMQ and its plugins.
+type policyKey struct {
+	kind string
+
+	// the accepted values of string keys, or any value when empty
+	values []string
+
+	// whether operator policies accept the key
+	operator bool
+}
+
+var policyKeys = map[string]policyKey{
+
+	"alternate-exchange":            {kind: policyKeyString},
+	"consumer-timeout":              {kind: policyKeyInteger},
+	"dead-letter-exchange":          {kind: policyKeyString},
+	"dead-letter-routing-key":       {kind: policyKeyString},
+	"dead-letter-strategy":          {kind: policyKeyString, values: []string{"at-most-once", "at-least-once"}},
+	"delivery-limit":                {kind: policyKeyInteger, operator: true},
+	"expires":                       {kind: policyKeyInteger, operator: true},
+	"federation-upstream":           {kind: policyKeyString},
+	"federation-upstream-set":       {kind: policyKeyString},
+	"ha-mode":                       {kind: policyKeyString, values: []string{"all", "exactly", "nodes"}},
+	"ha-params":                     {kind: policyKeyHaParams},
+	"ha-promote-on-failure":         {kind: policyKeyString, values: []string{"always", "when-synced"}},
+	"ha-promote-on-shutdown":        {kind: policyKeyString, values: []string{"always", "when-synced"}},
+	"ha-sync-batch-size":            {kind: policyKeyInteger},
+	"ha-sync-mode":                  {kind: policyKeyString, values: []string{"manual", "automatic"}},
+	"max-age":                       {kind: policyKeyString},
+	"max-in-memory-bytes":           {kind: policyKeyInteger, operator: true},
+	"max-in-memory-length":          {kind: policyKeyInteger, operator: true},
+	"max-length":                    {kind: policyKeyInteger, operator: true},
+	"max-length-bytes":              {kind: policyKeyInteger, operator: true},
+	"message-ttl":                   {kind: policyKeyInteger, operator: true},
+	"overflow":                      {kind: policyKeyString, values: []string{"drop-head", "reject-publish", "reject-publish-dlx"}},
+	"queue-leader-locator":          {kind: policyKeyString, values: []string{"client-local", "balanced"}},
+	"queue-master-locator":          {kind: policyKeyString, values: []string{"min-masters", "client-local", "random"}},
+	"queue-mode":                    {kind: policyKeyString, values: []string{"default", "lazy"}},
+	"queue-version":                 {kind: policyKeyInteger},
+	"stream-max-segment-size-bytes": {kind: policyKeyInteger},
+	"target-group-size":             {kind: policyKeyInteger, operator: true},
+}
+
+/*
+Returns the definition of a policy from either `definition` or
+`definition_json`. Values of `definition` are strings, so the ones
+that parse as integers are sent as integers, and the ha-params of
+ha-mode=nodes are sent as a list of nodes.
+*/
+func policyDefinition(policyMap map[string]interface{}) (map[string]interface{}, error) {
+
+	if v, ok := policyMap["definition_json"].(string); ok && v != "" {
+
+		var definition map[string]interface{}
+
+		if err := json.Unmarshal([]byte(v), &definition); err != nil {
+
+			return nil, err
+		}
+
+		return definition, nil
+	}
+
+	definition := make(map[string]interface{})
+
+	v, _ := policyMap["definition"].(map[string]interface{})
+
+	for key, val := range v {
+
+		if x, ok := val.(string); ok {
+
+			if x, err := strconv.ParseInt(x, 10, 64); err == nil {
+
+				val = x
+			}
+		}
+
+		definition[key] = val
+	}
+
+	// special case for ha-mode = nodes
+	if x, ok := definition["ha-mode"]; ok && x == "nodes" {
+
+		if nodes, ok := definition["ha-params"].(string); ok {
+
+			definition["ha-params"] = rabbithole.NodeNames(strings.Split(nodes, ","))
+		}
+	}
+
+	return definition, nil
+}
+
+// Checks the types and values of the known keys of a policy definition.
+func validatePolicyDefinition(definition map[string]interface{}, operator bool) error {
+
+	keys := make([]string, 0, len(definition))
+
+	for key := range definition {
+
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+
+		known, ok := policyKeys[key]
+
+		if !ok {
+
+			continue
+		}
+
+		if operator && !known.operator {
+
+			return fmt.Errorf("%s is not supported by operator policies", key)
+		}
+
+		value := definition[key]
+
+		switch known.kind {
+
+		case policyKeyInteger:
+			if !isPolicyInteger(value) {
+
+				return fmt.Errorf("%s must be a non-negative integer, got %#v", key, value)
+			}
+
+		case policyKeyString:
+			s, ok := value.(string)
+
+			if !ok {
+
+				return fmt.Errorf("%s must be a string, got %#v", key, value)
+			}
+
+			if len(known.values) > 0 && !containsString(known.values, s) {
+
+				return fmt.Errorf("%s must be one of %s, got %q", key, strings.Join(known.values, ", "), s)
+			}
+
+		case policyKeyHaParams:
+			if mode, _ := definition["ha-mode"].(string); mode == "exactly" && !isPolicyInteger(value) {
+
+				return fmt.Errorf("ha-params must be a number of replicas when ha-mode is exactly, got %#v", value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isPolicyInteger(value interface{}) bool {
+
+	switch v := value.(type) {
+
+	case int64:
+		return v >= 0
+
+	case int:
+		return v >= 0
+
+	case float64:
+		return v >= 0 && v == math.Trunc(v)
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+
+	for _, v := range values {
+
+		if v == value {
+
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Sets the definition read from the server in the attribute used by the
+configuration, keeping the types of the values for `definition_json`.
+*/
+func flattenPolicy(d *schema.ResourceData, pattern string, priority int, applyTo string, definition map[string]interface{}) map[string]interface{} {
+
+	p := map[string]interface{}{
+
+		"pattern":  pattern,
+		"priority": priority,
+		"apply_to": applyTo,
+	}
+
+	if _, ok := d.GetOk("policy.0.definition_json"); ok {
+
+		p["definition_json"] = toString(definition)
+
+	} else {
+
+		p["definition"] = flattenDefinition(definition)
+	}
+
+	return p
+}
+
+// Validates the definition of policies when planning.
+func customizePolicyDiff(operator bool) schema.CustomizeDiffFunc {
+
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+
+		// Unknown values are checked again once they are known.
+		if !d.NewValueKnown("policy.0.definition") || !d.NewValueKnown("policy.0.definition_json") {
+
+			return nil
+		}
+
+		policyList := d.Get("policy").([]interface{})
+
+		if len(policyList) == 0 || policyList[0] == nil {
+
+			return nil
+		}
+
+		definition, err := policyDefinition(policyList[0].(map[string]interface{}))
+
+		if err != nil {
+
+			return err
+		}
+
+		return validatePolicyDefinition(definition, operator)
+	}
+}
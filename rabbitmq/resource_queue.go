@@ -1,11 +1,16 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -15,12 +20,21 @@ import (
 
 func resourceQueue() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateQueue,
-		Read:   ReadQueue,
-		Delete: DeleteQueue,
+		CreateContext: CreateQueue,
+		ReadContext:   ReadQueue,
+		UpdateContext: UpdateQueue,
+		DeleteContext: DeleteQueue,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		CustomizeDiff: customizeQueueDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -36,10 +50,36 @@ func resourceQueue() *schema.Resource {
 				ForceNew: true,
 			},
 
+			// Arguments with a policy equivalent are moved to a generated
+			// policy so that they can be updated without recreating the queue.
+			"update_arguments_with_policy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			"arguments_policy_priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+
+			// Only known for quorum queues and streams.
+			"members": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"leader": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"settings": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -57,11 +97,63 @@ func resourceQueue() *schema.Resource {
 							ForceNew: true,
 						},
 
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								queueTypeClassic,
+								queueTypeQuorum,
+								queueTypeStream,
+							}, false),
+						},
+
+						// The settings below are declared as queue arguments
+						// and are changed like them.
+						"quorum_initial_group_size": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"max_length_bytes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"max_age": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+[YMDhms]$`), "must be a number followed by one of the units Y, M, D, h, m or s"),
+						},
+
+						"stream_max_segment_size_bytes": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"dead_letter_strategy": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"at-most-once",
+								"at-least-once",
+							}, false),
+						},
+
+						"delivery_limit": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
 						"arguments": {
 							Type:          schema.TypeMap,
 							Optional:      true,
 							ConflictsWith: []string{"settings.0.arguments_json"},
-							ForceNew:      true,
 						},
 
 						"arguments_json": {
@@ -70,7 +162,6 @@ func resourceQueue() *schema.Resource {
 							ValidateFunc:     validation.StringIsJSON,
 							ConflictsWith:    []string{"settings.0.arguments"},
 							DiffSuppressFunc: structure.SuppressJsonDiff,
-							ForceNew:         true,
 						},
 					},
 				},
@@ -79,8 +170,8 @@ func resourceQueue() *schema.Resource {
 	}
 }
 
-func CreateQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Get("name").(string)
 	vhost := d.Get("vhost").(string)
@@ -88,7 +179,7 @@ func CreateQueue(d *schema.ResourceData, meta interface{}) error {
 
 	settingsMap, ok := settingsList[0].(map[string]interface{})
 	if !ok {
-		return fmt.Errorf("Unable to parse settings")
+		return diag.Errorf("Unable to parse settings")
 	}
 
 	// If arguments_json is used, unmarshal it into a generic interface
@@ -97,7 +188,7 @@ func CreateQueue(d *schema.ResourceData, meta interface{}) error {
 		var arguments map[string]interface{}
 		err := json.Unmarshal([]byte(v), &arguments)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		delete(settingsMap, "arguments_json")
@@ -106,20 +197,39 @@ func CreateQueue(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(fmt.Sprintf("%s@%s@%s", name, vhost, toString(settingsMap)))
 
+	arguments, err := queueArguments(settingsList, queueTypedSettingsSet(d.GetRawConfig()))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	settingsMap["arguments"] = arguments
+
+	// Only the arguments without a policy equivalent are declared with the queue.
+	var definition map[string]interface{}
+	if d.Get("update_arguments_with_policy").(bool) {
+		arguments, _ := settingsMap["arguments"].(map[string]interface{})
+		settingsMap["arguments"], definition = splitQueueArguments(arguments)
+	}
+
 	if err := declareQueue(rmqc, vhost, name, settingsMap); err != nil {
 
-		return err
+		return diag.FromErr(err)
 	}
 
-	return ReadQueue(d, meta)
+	if len(definition) > 0 {
+		if err := putQueueArgumentsPolicy(rmqc, vhost, name, d.Get("arguments_policy_priority").(int), definition); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadQueue(ctx, d, meta)
 }
 
-func ReadQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	queueId := strings.Split(d.Id(), "@")
 	if len(queueId) < 2 {
-		return fmt.Errorf("Unable to determine Queue ID")
+		return diag.Errorf("Unable to determine Queue ID")
 	}
 
 	user := queueId[0]
@@ -127,15 +237,41 @@ func ReadQueue(d *schema.ResourceData, meta interface{}) error {
 
 	queueSettings, err := rmqc.GetQueue(vhost, user)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Queue retrieved for %s: %#v", d.Id(), queueSettings)
 
 	d.Set("name", queueSettings.Name)
 	d.Set("vhost", queueSettings.Vhost)
+	d.Set("members", queueSettings.Members)
+	d.Set("leader", queueSettings.Leader)
+
+	// Not known to the server, so imported queues and queues created by earlier
+	// versions fall back to the defaults instead of being recreated.
+	d.Set("update_arguments_with_policy", d.Get("update_arguments_with_policy"))
+	d.Set("arguments_policy_priority", d.Get("arguments_policy_priority"))
 
-	e := make(map[string]interface{})
+	_, usesJson := d.GetOk("settings.0.arguments_json")
+
+	arguments := queueSettings.Arguments
+	if d.Get("update_arguments_with_policy").(bool) {
+		definition, err := getQueueArgumentsPolicy(rmqc, vhost, user)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		arguments = mergeQueueArguments(queueSettings.Arguments, definition, !usesJson)
+	}
+
+	// Arguments are moved to their typed settings unless declared as arguments.
+	declared, err := queueArgumentsSetting(d.Get("settings").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	arguments, e := extractQueueTypedArguments(arguments, declared)
+	e["type"] = queueTypeOf(rabbithole.QueueInfo(*queueSettings))
 	e["durable"] = queueSettings.Durable
 	e["auto_delete"] = queueSettings.AutoDelete
 
@@ -147,39 +283,89 @@ func ReadQueue(d *schema.ResourceData, meta interface{}) error {
 	// `arguments` cannot receive any values other than a string (d.Set will fail), therefore any drift
 	// containing nonstring values AND the configuration originated from `arguments`,
 	// will now be encoded to `arguments_json`.
-	if _, ok := d.GetOk("settings.0.arguments_json"); ok || nonStringInArguments(queueSettings.Arguments) {
-		bytes, err := json.Marshal(queueSettings.Arguments)
+	if usesJson || nonStringInArguments(arguments) {
+		bytes, err := json.Marshal(arguments)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		e["arguments_json"] = string(bytes)
 	} else {
-		e["arguments"] = queueSettings.Arguments
+		e["arguments"] = arguments
 	}
 
 	queue := make([]map[string]interface{}, 1)
 	queue[0] = e
 
-	return d.Set("settings", queue)
+	return diag.FromErr(d.Set("settings", queue))
+}
+
+func UpdateQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	queueId := strings.Split(d.Id(), "@")
+	if len(queueId) < 2 {
+		return diag.Errorf("Unable to determine Queue ID")
+	}
+
+	name := queueId[0]
+	vhost := queueId[1]
+
+	if !d.Get("update_arguments_with_policy").(bool) {
+		return ReadQueue(ctx, d, meta)
+	}
+
+	if d.HasChanges("settings", "arguments_policy_priority") {
+		oldSettings, newSettings := d.GetChange("settings")
+
+		set := queueTypedSettingsSet(d.GetRawConfig())
+
+		oldArguments, err := queueArguments(oldSettings.([]interface{}), set)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		newArguments, err := queueArguments(newSettings.([]interface{}), set)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if keys := immutableQueueArgumentChanges(oldArguments, newArguments); len(keys) > 0 {
+			return diag.Errorf("Unable to update queue arguments in place: %s", strings.Join(keys, ", "))
+		}
+
+		_, definition := splitQueueArguments(newArguments)
+
+		if err := putQueueArgumentsPolicy(rmqc, vhost, name, d.Get("arguments_policy_priority").(int), definition); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadQueue(ctx, d, meta)
 }
 
-func DeleteQueue(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteQueue(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	queueId := strings.Split(d.Id(), "@")
 	if len(queueId) < 2 {
-		return fmt.Errorf("Unable to determine Queue ID")
+		return diag.Errorf("Unable to determine Queue ID")
 	}
 
 	user := queueId[0]
 	vhost := queueId[1]
 
+	if d.Get("update_arguments_with_policy").(bool) {
+		if err := putQueueArgumentsPolicy(rmqc, vhost, user, 0, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete queue for %s", d.Id())
 
 	resp, err := rmqc.DeleteQueue(vhost, user)
 	log.Printf("[DEBUG] RabbitMQ: Queue delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -188,7 +374,7 @@ func DeleteQueue(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ queue: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ queue: %s", resp.Status)
 	}
 
 	return nil
@@ -235,3 +421,184 @@ func nonStringInArguments(args map[string]interface{}) bool {
 	}
 	return false
 }
+
+/*
+Validates the settings against the queue type and forces a new queue
+when arguments change that the generated policy cannot carry.
+*/
+func customizeQueueDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Unknown values are checked again once they are known.
+	known := true
+	for _, key := range []string{"settings", "settings.0.durable", "settings.0.auto_delete", "settings.0.arguments", "settings.0.arguments_json"} {
+		known = known && d.NewValueKnown(key)
+	}
+
+	// the type is computed when not set, so only the configuration tells whether it is known
+	if config := d.GetRawConfig(); known && !config.IsNull() {
+		if raw := config.GetAttr("settings"); raw.IsKnown() && !raw.IsNull() && raw.LengthInt() > 0 {
+			known = raw.Index(cty.NumberIntVal(0)).GetAttr("type").IsKnown()
+		}
+	}
+
+	if known {
+		settingsList := d.Get("settings").([]interface{})
+
+		arguments, err := queueArgumentsSetting(settingsList)
+		if err != nil {
+			return err
+		}
+
+		if len(settingsList) > 0 && settingsList[0] != nil {
+			if err := validateQueueSettings(settingsList[0].(map[string]interface{}), queueTypedSettingsSet(d.GetRawConfig()), arguments); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	keys := []string{}
+	for _, key := range []string{"settings.0.arguments", "settings.0.arguments_json"} {
+		if d.HasChange(key) {
+			keys = append(keys, key)
+		}
+	}
+	for _, typed := range queueTypedArguments {
+		if key := "settings.0." + typed.key; typed.key != "type" && d.HasChange(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	forceNew := !d.Get("update_arguments_with_policy").(bool)
+
+	// Unknown values are checked again by UpdateQueue once they are known.
+	if !forceNew && d.NewValueKnown("settings.0.arguments") && d.NewValueKnown("settings.0.arguments_json") {
+		oldSettings, newSettings := d.GetChange("settings")
+
+		// the state holds 0 for unset settings as well, so the configuration tells which are set
+		set := queueTypedSettingsSet(d.GetRawConfig())
+
+		oldArguments, err := queueArguments(oldSettings.([]interface{}), set)
+		if err != nil {
+			return err
+		}
+
+		newArguments, err := queueArguments(newSettings.([]interface{}), set)
+		if err != nil {
+			return err
+		}
+
+		forceNew = len(immutableQueueArgumentChanges(oldArguments, newArguments)) > 0
+	}
+
+	if forceNew {
+		for _, key := range keys {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the queue arguments from `arguments` or `arguments_json` and from the typed settings.
+func queueArguments(settingsList []interface{}, set map[string]bool) (map[string]interface{}, error) {
+	arguments, err := queueArgumentsSetting(settingsList)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(settingsList) == 0 || settingsList[0] == nil {
+		return arguments, nil
+	}
+
+	for key, value := range queueTypedArgumentValues(settingsList[0].(map[string]interface{}), set) {
+		arguments[key] = value
+	}
+
+	return arguments, nil
+}
+
+// Returns the queue arguments from either `arguments` or `arguments_json`.
+func queueArgumentsSetting(settingsList []interface{}) (map[string]interface{}, error) {
+	arguments := map[string]interface{}{}
+
+	if len(settingsList) == 0 || settingsList[0] == nil {
+		return arguments, nil
+	}
+
+	settingsMap := settingsList[0].(map[string]interface{})
+
+	if v, ok := settingsMap["arguments_json"].(string); ok && v != "" {
+		if err := json.Unmarshal([]byte(v), &arguments); err != nil {
+			return nil, err
+		}
+
+		return arguments, nil
+	}
+
+	if v, ok := settingsMap["arguments"].(map[string]interface{}); ok {
+		for key, value := range v {
+			arguments[key] = value
+		}
+	}
+
+	return arguments, nil
+}
+
+func getQueueArgumentsPolicy(rmqc *rabbithole.Client, vhost string, name string) (map[string]interface{}, error) {
+	policy, err := rmqc.GetPolicy(vhost, queueArgumentsPolicyName(name))
+
+	var errorResponse rabbithole.ErrorResponse
+	if errors.As(err, &errorResponse) && errorResponse.StatusCode == 404 {
+		return map[string]interface{}{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Queue arguments policy retrieved for %s@%s: %#v", name, vhost, policy)
+
+	return policy.Definition, nil
+}
+
+// Declares the generated policy, or deletes it when the definition is empty.
+func putQueueArgumentsPolicy(rmqc *rabbithole.Client, vhost string, name string, priority int, definition map[string]interface{}) error {
+	policyName := queueArgumentsPolicyName(name)
+
+	if len(definition) > 0 {
+		return putPolicy(rmqc, vhost, policyName, map[string]interface{}{
+			"pattern":    queueArgumentsPolicyPattern(name),
+			"priority":   priority,
+			"apply_to":   "queues",
+			"definition": definition,
+		})
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete queue arguments policy for %s@%s", name, vhost)
+
+	resp, err := rmqc.DeletePolicy(vhost, policyName)
+	log.Printf("[DEBUG] RabbitMQ: Queue arguments policy delete response: %#v", resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == 404 {
+		// there was nothing to carry
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error deleting RabbitMQ queue arguments policy: %s", resp.Status)
+	}
+
+	return nil
+}
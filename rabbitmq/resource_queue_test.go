@@ -1,12 +1,14 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
@@ -54,6 +56,153 @@ func TestAccQueue_jsonArguments(t *testing.T) {
 	})
 }
 
+func TestAccQueue_argumentsPolicy(t *testing.T) {
+	var queueInfo rabbithole.QueueInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccQueueCheckDestroy(&queueInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueueConfig_argumentsPolicy(`{"x-message-ttl": 5000, "x-max-priority": 10}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					testAccQueueCheckArgumentsPolicy("rabbitmq_queue.test", "message-ttl", 5000),
+				),
+			},
+			{
+				Config: testAccQueueConfig_argumentsPolicy(`{"x-message-ttl": 10000, "x-max-priority": 10}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					testAccQueueCheckArgumentsPolicy("rabbitmq_queue.test", "message-ttl", 10000),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQueue_quorum(t *testing.T) {
+	var queueInfo rabbithole.QueueInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccQueueCheckDestroy(&queueInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueueConfig_quorum,
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "settings.0.type", "quorum"),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "settings.0.delivery_limit", "5"),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "settings.0.arguments.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccQueue_stream(t *testing.T) {
+	var queueInfo rabbithole.QueueInfo
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccQueueCheckDestroy(&queueInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueueConfig_stream,
+				Check: resource.ComposeTestCheckFunc(
+					testAccQueueCheck("rabbitmq_queue.test", &queueInfo),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "settings.0.type", "stream"),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "settings.0.max_age", "7D"),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "settings.0.max_length_bytes", "1000000000"),
+				),
+			},
+		},
+	})
+}
+
+func TestQueueSettingsValidation(t *testing.T) {
+	for name, test := range map[string]struct {
+		settings map[string]interface{}
+		valid    bool
+	}{
+		"quorum": {
+			settings: map[string]interface{}{"type": "quorum", "durable": true, "delivery_limit": 5, "dead_letter_strategy": "at-least-once"},
+			valid:    true,
+		},
+		"quorum in arguments": {
+			settings: map[string]interface{}{"durable": true, "quorum_initial_group_size": 3, "arguments": map[string]interface{}{"x-queue-type": "quorum"}},
+			valid:    true,
+		},
+		"auto-deleted quorum": {
+			settings: map[string]interface{}{"type": "quorum", "durable": true, "auto_delete": true},
+		},
+		"non-durable stream": {
+			settings: map[string]interface{}{"type": "stream", "durable": false},
+		},
+		"stream": {
+			settings: map[string]interface{}{"type": "stream", "durable": true, "max_age": "7D", "stream_max_segment_size_bytes": 50000000},
+			valid:    true,
+		},
+		"delivery limit on a classic queue": {
+			settings: map[string]interface{}{"delivery_limit": 5},
+		},
+		"max age on a quorum queue": {
+			settings: map[string]interface{}{"type": "quorum", "durable": true, "max_age": "7D"},
+		},
+		"conflicting argument": {
+			settings: map[string]interface{}{"type": "stream", "durable": true, "arguments": map[string]interface{}{"x-queue-type": "quorum"}},
+		},
+	} {
+		_, err := resourceQueue().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":     "test",
+			"settings": []interface{}{test.settings},
+		}), nil)
+
+		if test.valid && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestQueueArguments_zero(t *testing.T) {
+	settings := map[string]cty.Value{
+		"type":                          cty.StringVal("quorum"),
+		"quorum_initial_group_size":     cty.NullVal(cty.Number),
+		"max_length_bytes":              cty.NullVal(cty.Number),
+		"max_age":                       cty.NullVal(cty.String),
+		"stream_max_segment_size_bytes": cty.NullVal(cty.Number),
+		"dead_letter_strategy":          cty.NullVal(cty.String),
+		"delivery_limit":                cty.NumberIntVal(0),
+	}
+	config := cty.ObjectVal(map[string]cty.Value{
+		"settings": cty.ListVal([]cty.Value{cty.ObjectVal(settings)}),
+	})
+
+	set := queueTypedSettingsSet(config)
+	if !reflect.DeepEqual(set, map[string]bool{"type": true, "delivery_limit": true}) {
+		t.Fatalf("expected type and delivery_limit to be set, got %v", set)
+	}
+
+	arguments, err := queueArguments([]interface{}{map[string]interface{}{
+		"type":             "quorum",
+		"durable":          true,
+		"max_length_bytes": 0,
+		"delivery_limit":   0,
+	}}, set)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]interface{}{"x-queue-type": "quorum", "x-delivery-limit": 0}
+	if !reflect.DeepEqual(arguments, expected) {
+		t.Errorf("expected arguments %v, got %v", expected, arguments)
+	}
+}
+
 func testAccQueueCheck(rn string, queueInfo *rabbithole.QueueInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -65,7 +214,7 @@ func testAccQueueCheck(rn string, queueInfo *rabbithole.QueueInfo) resource.Test
 			return fmt.Errorf("queue id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		queueParts := strings.Split(rs.Primary.ID, "@")
 
 		queues, err := rmqc.ListQueuesIn(queueParts[1])
@@ -110,9 +259,41 @@ func testAccQueueCheckJsonArguments(rn string, queueInfo *rabbithole.QueueInfo,
 	}
 }
 
+func testAccQueueCheckArgumentsPolicy(rn string, key string, value float64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		queueParts := strings.Split(rs.Primary.ID, "@")
+
+		policy, err := rmqc.GetPolicy(queueParts[1], queueArgumentsPolicyName(queueParts[0]))
+		if err != nil {
+			return fmt.Errorf("Error retrieving queue arguments policy: %s", err)
+		}
+
+		if policy.Definition[key] != value {
+			return fmt.Errorf("Queue arguments policy %s is %v, expected %v", key, policy.Definition[key], value)
+		}
+
+		queue, err := rmqc.GetQueue(queueParts[1], queueParts[0])
+		if err != nil {
+			return fmt.Errorf("Error retrieving queue: %s", err)
+		}
+
+		if _, ok := queue.Arguments["x-message-ttl"]; ok {
+			return fmt.Errorf("Queue was declared with x-message-ttl")
+		}
+
+		return nil
+	}
+}
+
 func testAccQueueCheckDestroy(queueInfo *rabbithole.QueueInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 		queues, err := rmqc.ListQueuesIn(queueInfo.Vhost)
 		if err != nil && !strings.Contains(strings.ToLower(err.Error()), "not found") {
@@ -209,3 +390,94 @@ resource "rabbitmq_queue" "test" {
 	}
 }`, j)
 }
+
+func testAccQueueConfig_argumentsPolicy(j string) string {
+	return fmt.Sprintf(`
+variable "arguments" {
+	default = <<EOF
+%s
+EOF
+}
+
+resource "rabbitmq_vhost" "test" {
+	name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+	user = "guest"
+	vhost = "${rabbitmq_vhost.test.name}"
+	permissions {
+		configure = ".*"
+		write = ".*"
+		read = ".*"
+	}
+}
+
+resource "rabbitmq_queue" "test" {
+	name = "test"
+	vhost = "${rabbitmq_permissions.guest.vhost}"
+	update_arguments_with_policy = true
+	settings {
+		durable = false
+		auto_delete = true
+		arguments_json = "${var.arguments}"
+	}
+}`, j)
+}
+
+const testAccQueueConfig_quorum = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        type = "quorum"
+        durable = true
+        quorum_initial_group_size = 1
+        dead_letter_strategy = "at-most-once"
+        delivery_limit = 5
+        arguments = {
+            "x-max-length" = "1000"
+        }
+    }
+}`
+
+const testAccQueueConfig_stream = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        type = "stream"
+        durable = true
+        max_age = "7D"
+        max_length_bytes = 1000000000
+        stream_max_segment_size_bytes = 50000000
+    }
+}`
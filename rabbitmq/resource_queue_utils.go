@@ -0,0 +1,372 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+/*
+Maps optional queue arguments to the policy keys that can carry
+the same setting. Arguments missing from this map cannot be changed
+once the queue has been declared.
+*/
+var queueArgumentPolicyKeys = map[string]string{
+
+	"x-message-ttl":                   "message-ttl",
+	"x-expires":                       "expires",
+	"x-max-length":                    "max-length",
+	"x-max-length-bytes":              "max-length-bytes",
+	"x-max-in-memory-length":          "max-in-memory-length",
+	"x-max-in-memory-bytes":           "max-in-memory-bytes",
+	"x-overflow":                      "overflow",
+	"x-dead-letter-exchange":          "dead-letter-exchange",
+	"x-dead-letter-routing-key":       "dead-letter-routing-key",
+	"x-dead-letter-strategy":          "dead-letter-strategy",
+	"x-delivery-limit":                "delivery-limit",
+	"x-queue-mode":                    "queue-mode",
+	"x-queue-master-locator":          "queue-master-locator",
+	"x-queue-leader-locator":          "queue-leader-locator",
+	"x-max-age":                       "max-age",
+	"x-stream-max-segment-size-bytes": "stream-max-segment-size-bytes",
+}
+
+// Returns the name of the policy generated for the queue arguments.
+func queueArgumentsPolicyName(queue string) string {
+
+	return fmt.Sprintf("terraform-queue-%s", queue)
+}
+
+// Returns a pattern that matches the queue name and nothing else.
+func queueArgumentsPolicyPattern(queue string) string {
+
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(queue))
+}
+
+/*
+Splits queue arguments into the ones that must be declared
+along with the queue and the policy definition carrying the rest.
+*/
+func splitQueueArguments(arguments map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+
+	declared := make(map[string]interface{})
+	definition := make(map[string]interface{})
+
+	for key, value := range arguments {
+
+		if policyKey, ok := queueArgumentPolicyKeys[key]; ok {
+
+			// policies reject numbers passed as strings
+			if x, ok := value.(string); ok {
+
+				if x, err := strconv.ParseInt(x, 10, 64); err == nil {
+
+					value = x
+				}
+			}
+
+			definition[policyKey] = value
+
+			continue
+		}
+
+		declared[key] = value
+	}
+
+	return declared, definition
+}
+
+/*
+Merges the generated policy definition back into the declared
+queue arguments. When stringify is set, numbers are formatted
+as strings so they fit the string-only arguments map.
+*/
+func mergeQueueArguments(declared map[string]interface{}, definition map[string]interface{}, stringify bool) map[string]interface{} {
+
+	arguments := make(map[string]interface{})
+
+	for key, value := range declared {
+
+		arguments[key] = value
+	}
+
+	for argument, policyKey := range queueArgumentPolicyKeys {
+
+		value, ok := definition[policyKey]
+
+		if !ok {
+
+			continue
+		}
+
+		if v, ok := value.(float64); ok && stringify {
+
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+
+		arguments[argument] = value
+	}
+
+	return arguments
+}
+
+/*
+Returns the argument keys that were added, removed or changed
+and cannot be carried by the generated policy.
+*/
+func immutableQueueArgumentChanges(old map[string]interface{}, new map[string]interface{}) []string {
+
+	var keys []string
+
+	for key, value := range new {
+
+		if _, ok := queueArgumentPolicyKeys[key]; ok {
+
+			continue
+		}
+
+		if previous, ok := old[key]; !ok || fmt.Sprint(previous) != fmt.Sprint(value) {
+
+			keys = append(keys, key)
+		}
+	}
+
+	for key := range old {
+
+		if _, ok := queueArgumentPolicyKeys[key]; ok {
+
+			continue
+		}
+
+		if _, ok := new[key]; !ok {
+
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+const (
+	queueTypeClassic = "classic"
+	queueTypeQuorum  = "quorum"
+	queueTypeStream  = "stream"
+)
+
+// Describes a queue setting that is declared as a queue argument.
+type queueTypedArgument struct {
+
+	// the key in the settings block
+	key string
+
+	argument string
+
+	// the queue types accepting the argument, or all of them when empty
+	queueTypes []string
+}
+
+var queueTypedArguments = []queueTypedArgument{
+
+	{key: "type", argument: "x-queue-type"},
+	{key: "quorum_initial_group_size", argument: "x-quorum-initial-group-size", queueTypes: []string{queueTypeQuorum}},
+	{key: "max_length_bytes", argument: "x-max-length-bytes"},
+	{key: "max_age", argument: "x-max-age", queueTypes: []string{queueTypeStream}},
+	{key: "stream_max_segment_size_bytes", argument: "x-stream-max-segment-size-bytes", queueTypes: []string{queueTypeStream}},
+	{key: "dead_letter_strategy", argument: "x-dead-letter-strategy", queueTypes: []string{queueTypeQuorum}},
+	{key: "delivery_limit", argument: "x-delivery-limit", queueTypes: []string{queueTypeQuorum}},
+}
+
+/*
+Returns the keys of the typed settings set in the configuration, since
+an explicit 0 cannot be told apart from an unset setting otherwise.
+*/
+func queueTypedSettingsSet(config cty.Value) map[string]bool {
+
+	set := make(map[string]bool)
+
+	if config.IsNull() || !config.IsKnown() {
+
+		return set
+	}
+
+	raw := config.GetAttr("settings")
+
+	if raw.IsNull() || !raw.IsKnown() || raw.LengthInt() == 0 {
+
+		return set
+	}
+
+	settings := raw.Index(cty.NumberIntVal(0))
+
+	if settings.IsNull() || !settings.IsKnown() {
+
+		return set
+	}
+
+	for _, typed := range queueTypedArguments {
+
+		if !settings.GetAttr(typed.key).IsNull() {
+
+			set[typed.key] = true
+		}
+	}
+
+	return set
+}
+
+/*
+Returns the arguments set through the typed settings, leaving out the
+unset ones. Zero values are only kept when set in the configuration.
+*/
+func queueTypedArgumentValues(settingsMap map[string]interface{}, set map[string]bool) map[string]interface{} {
+
+	arguments := make(map[string]interface{})
+
+	for _, typed := range queueTypedArguments {
+
+		switch value := settingsMap[typed.key].(type) {
+
+		case string:
+			if value != "" {
+
+				arguments[typed.argument] = value
+			}
+
+		case int:
+			if value != 0 || set[typed.key] {
+
+				arguments[typed.argument] = value
+			}
+		}
+	}
+
+	return arguments
+}
+
+/*
+Moves the arguments having a typed setting out of the arguments read
+from the server, unless they were declared as arguments in the first
+place. Numbers are converted to integers for the typed settings.
+*/
+func extractQueueTypedArguments(arguments map[string]interface{}, declared map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+
+	remaining := make(map[string]interface{})
+
+	for key, value := range arguments {
+
+		remaining[key] = value
+	}
+
+	settings := make(map[string]interface{})
+
+	for _, typed := range queueTypedArguments {
+
+		value, ok := remaining[typed.argument]
+
+		if !ok {
+
+			continue
+		}
+
+		if _, ok := declared[typed.argument]; ok {
+
+			continue
+		}
+
+		delete(remaining, typed.argument)
+
+		switch v := value.(type) {
+
+		case float64:
+			settings[typed.key] = int(v)
+
+		case string:
+			if typed.key == "type" || typed.key == "max_age" || typed.key == "dead_letter_strategy" {
+
+				settings[typed.key] = v
+
+			} else if n, err := strconv.Atoi(v); err == nil {
+
+				settings[typed.key] = n
+			}
+		}
+	}
+
+	return remaining, settings
+}
+
+/*
+Rejects the settings that RabbitMQ refuses for the queue type, so
+that mistakes show up when planning rather than as an error response.
+*/
+func validateQueueSettings(settingsMap map[string]interface{}, set map[string]bool, arguments map[string]interface{}) error {
+
+	typedValues := queueTypedArgumentValues(settingsMap, set)
+
+	for _, typed := range queueTypedArguments {
+
+		value, ok := typedValues[typed.argument]
+
+		if !ok {
+
+			continue
+		}
+
+		if declared, ok := arguments[typed.argument]; ok && fmt.Sprint(declared) != fmt.Sprint(value) {
+
+			return fmt.Errorf("settings.0.%s conflicts with the %s argument", typed.key, typed.argument)
+		}
+	}
+
+	queueType := queueTypeClassic
+
+	if v, ok := typedValues["x-queue-type"].(string); ok {
+
+		queueType = v
+
+	} else if v, ok := arguments["x-queue-type"].(string); ok {
+
+		queueType = v
+	}
+
+	durable, _ := settingsMap["durable"].(bool)
+	autoDelete, _ := settingsMap["auto_delete"].(bool)
+
+	if queueType == queueTypeQuorum || queueType == queueTypeStream {
+
+		if !durable {
+
+			return fmt.Errorf("%s queues must be durable", queueType)
+		}
+
+		if autoDelete {
+
+			return fmt.Errorf("%s queues cannot be auto-deleted", queueType)
+		}
+	}
+
+	for _, typed := range queueTypedArguments {
+
+		if _, ok := typedValues[typed.argument]; !ok || len(typed.queueTypes) == 0 {
+
+			continue
+		}
+
+		accepted := false
+
+		for _, t := range typed.queueTypes {
+
+			accepted = accepted || t == queueType
+		}
+
+		if !accepted {
+
+			return fmt.Errorf("settings.0.%s is only supported by %s queues, not by %s queues", typed.key, strings.Join(typed.queueTypes, ", "), queueType)
+		}
+	}
+
+	return nil
+}
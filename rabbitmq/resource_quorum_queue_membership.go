@@ -0,0 +1,176 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceQuorumQueueMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateQuorumQueueMembership,
+		UpdateContext: UpdateQuorumQueueMembership,
+		ReadContext:   ReadQuorumQueueMembership,
+		DeleteContext: DeleteQuorumQueueMembership,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"queue": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+				ForceNew: true,
+			},
+
+			// The nodes hosting a replica of the queue, which are added
+			// or removed one by one to match the configuration.
+			"members": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"leader": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func CreateQuorumQueueMembership(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	queue := d.Get("queue").(string)
+	vhost := d.Get("vhost").(string)
+
+	if err := putQuorumQueueMembers(ctx, meta.(*rabbitmqClient), vhost, queue, d.Get("members").(*schema.Set)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s@%s", queue, vhost))
+
+	return ReadQuorumQueueMembership(ctx, d, meta)
+}
+
+func ReadQuorumQueueMembership(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	queue, vhost, err := parseQuorumQueueMembershipId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	queueInfo, err := rmqc.GetQueue(vhost, queue)
+	if err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Quorum queue members retrieved for %s: %#v", d.Id(), queueInfo.Members)
+
+	d.Set("queue", queueInfo.Name)
+	d.Set("vhost", queueInfo.Vhost)
+	d.Set("members", queueInfo.Members)
+	d.Set("leader", queueInfo.Leader)
+
+	return nil
+}
+
+func UpdateQuorumQueueMembership(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	queue, vhost, err := parseQuorumQueueMembershipId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("members") {
+		if err := putQuorumQueueMembers(ctx, meta.(*rabbitmqClient), vhost, queue, d.Get("members").(*schema.Set)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadQuorumQueueMembership(ctx, d, meta)
+}
+
+func DeleteQuorumQueueMembership(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Removing replicas would put the messages of the queue at risk,
+	// so the members are left as they are.
+	log.Printf("[DEBUG] RabbitMQ: Leaving the members of quorum queue %s unchanged", d.Id())
+
+	return nil
+}
+
+func parseQuorumQueueMembershipId(id string) (string, string, error) {
+	parts := strings.Split(id, "@")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("Unable to determine quorum queue membership ID: %s", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+/*
+Adds the missing members of the quorum queue before removing the extra
+ones, so that the queue keeps as many replicas as possible meanwhile.
+*/
+func putQuorumQueueMembers(ctx context.Context, rmqc *rabbitmqClient, vhost string, queue string, members *schema.Set) error {
+	queueInfo, err := rmqc.WithContext(ctx).GetQueue(vhost, queue)
+	if err != nil {
+		return err
+	}
+
+	if queueTypeOf(rabbithole.QueueInfo(*queueInfo)) != queueTypeQuorum {
+		return fmt.Errorf("Queue %s@%s is not a quorum queue", queue, vhost)
+	}
+
+	current := schema.NewSet(schema.HashString, nil)
+	for _, member := range queueInfo.Members {
+		current.Add(member)
+	}
+
+	path := fmt.Sprintf("queues/quorum/%s/%s/replicas", url.PathEscape(vhost), url.PathEscape(queue))
+
+	for _, member := range members.Difference(current).List() {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to add member %s to quorum queue %s@%s", member, queue, vhost)
+
+		err := rmqc.executeJSONRequest(ctx, http.MethodPost, path+"/add", map[string]interface{}{"node": member}, nil)
+		if err != nil {
+			return fmt.Errorf("Error adding member %s to quorum queue %s@%s: %s", member, queue, vhost, err)
+		}
+	}
+
+	for _, member := range current.Difference(members).List() {
+		log.Printf("[DEBUG] RabbitMQ: Attempting to remove member %s from quorum queue %s@%s", member, queue, vhost)
+
+		err := rmqc.executeJSONRequest(ctx, http.MethodDelete, path+"/delete", map[string]interface{}{"node": member}, nil)
+		if err != nil {
+			return fmt.Errorf("Error removing member %s from quorum queue %s@%s: %s", member, queue, vhost, err)
+		}
+	}
+
+	return nil
+}
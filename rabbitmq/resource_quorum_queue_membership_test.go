@@ -0,0 +1,92 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccQuorumQueueMembership(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQuorumQueueMembershipConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccQuorumQueueMembershipCheck("rabbitmq_quorum_queue_membership.test"),
+					resource.TestCheckResourceAttrPair(
+						"rabbitmq_queue.test", "leader", "rabbitmq_quorum_queue_membership.test", "leader"),
+					resource.TestCheckResourceAttr("rabbitmq_queue.test", "members.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "rabbitmq_quorum_queue_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccQuorumQueueMembershipCheck(rn string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		queue, vhost, err := parseQuorumQueueMembershipId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		queueInfo, err := rmqc.GetQueue(vhost, queue)
+		if err != nil {
+			return fmt.Errorf("Error retrieving queue: %s", err)
+		}
+
+		if members := rs.Primary.Attributes["members.#"]; members != fmt.Sprint(len(queueInfo.Members)) {
+			return fmt.Errorf("Expected queue %s to have %s members, got %v", rs.Primary.ID, members, queueInfo.Members)
+		}
+
+		if rs.Primary.Attributes["leader"] != queueInfo.Leader {
+			return fmt.Errorf("Expected the leader of queue %s to be %s, got %s", rs.Primary.ID, rs.Primary.Attributes["leader"], queueInfo.Leader)
+		}
+
+		return nil
+	}
+}
+
+const testAccQuorumQueueMembershipConfig = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "test"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        type = "quorum"
+        durable = true
+    }
+}
+
+resource "rabbitmq_quorum_queue_membership" "test" {
+    queue = rabbitmq_queue.test.name
+    vhost = rabbitmq_queue.test.vhost
+    members = rabbitmq_queue.test.members
+}`
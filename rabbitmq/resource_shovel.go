@@ -1,23 +1,32 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceShovel() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateShovel,
-		Read:   ReadShovel,
-		Delete: DeleteShovel,
+		CreateContext: CreateShovel,
+		UpdateContext: UpdateShovel,
+		ReadContext:   ReadShovel,
+		DeleteContext: DeleteShovel,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -30,10 +39,17 @@ func resourceShovel() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			// Waits for the shovel to run after creating or updating it,
+			// within the create and update timeouts.
+			"wait_for_running": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Changes re-declare the shovel, which restarts it in place.
 			"info": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -110,9 +126,19 @@ func resourceShovel() *schema.Resource {
 							Optional:      true,
 						},
 						"destination_uri": {
-							Type:      schema.TypeString,
-							Required:  true,
-							Sensitive: false,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"info.0.destination_uri", "info.0.destination_uris"},
+						},
+						// Tried in turn, e.g. to fail over between the nodes of a cluster.
+						"destination_uris": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							Sensitive:    true,
+							MinItems:     1,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ExactlyOneOf: []string{"info.0.destination_uri", "info.0.destination_uris"},
 						},
 						"prefetch_count": {
 							Type:          schema.TypeInt,
@@ -166,9 +192,19 @@ func resourceShovel() *schema.Resource {
 							Optional:      true,
 						},
 						"source_uri": {
-							Type:      schema.TypeString,
-							Required:  true,
-							Sensitive: false,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ExactlyOneOf: []string{"info.0.source_uri", "info.0.source_uris"},
+						},
+						// Tried in turn, e.g. to fail over between the nodes of a cluster.
+						"source_uris": {
+							Type:         schema.TypeList,
+							Optional:     true,
+							Sensitive:    true,
+							MinItems:     1,
+							Elem:         &schema.Schema{Type: schema.TypeString},
+							ExactlyOneOf: []string{"info.0.source_uri", "info.0.source_uris"},
 						},
 					},
 				},
@@ -177,36 +213,31 @@ func resourceShovel() *schema.Resource {
 	}
 }
 
-func CreateShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateShovel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	vhost := d.Get("vhost").(string)
 	shovelName := d.Get("name").(string)
-	shovelInfo := d.Get("info").([]interface{})
-
-	shovelMap, ok := shovelInfo[0].(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("Unable to parse shovel info")
-	}
 
-	shovelDefinition := setShovelDefinition(shovelMap).(rabbithole.ShovelDefinition)
-
-	log.Printf("[DEBUG] RabbitMQ: Attempting to declare shovel %s in vhost %s", shovelName, vhost)
-	resp, err := rmqc.DeclareShovel(vhost, shovelName, shovelDefinition)
-	log.Printf("[DEBUG] RabbitMQ: shovel declartion response: %#v", resp)
-	if err != nil {
-		return err
+	if err := declareShovel(rmqc, vhost, shovelName, d.Get("info").([]interface{})); err != nil {
+		return diag.FromErr(err)
 	}
 
 	shovelId := fmt.Sprintf("%s@%s", shovelName, vhost)
 
 	d.SetId(shovelId)
 
-	return ReadShovel(d, meta)
+	if d.Get("wait_for_running").(bool) {
+		if err := waitForShovelRunning(ctx, meta.(*rabbitmqClient), vhost, shovelName, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadShovel(ctx, d, meta)
 }
 
-func ReadShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadShovel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	shovelId := strings.Split(d.Id(), "@")
 
@@ -215,7 +246,7 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 
 	shovelInfo, err := rmqc.GetShovel(vhost, name)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Shovel retrieved: Vhost: %#v, Name: %#v", vhost, name)
@@ -234,9 +265,7 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 	info["destination_protocol"] = shovelInfo.Definition.DestinationProtocol
 	info["destination_publish_properties"] = shovelInfo.Definition.DestinationPublishProperties
 	info["destination_queue"] = shovelInfo.Definition.DestinationQueue
-	if len(shovelInfo.Definition.DestinationURI) > 0 {
-		info["destination_uri"] = shovelInfo.Definition.DestinationURI[0]
-	}
+	setShovelURIs(d, info, "destination", shovelInfo.Definition.DestinationURI)
 	info["prefetch_count"] = shovelInfo.Definition.PrefetchCount
 	info["reconnect_delay"] = shovelInfo.Definition.ReconnectDelay
 	info["source_address"] = shovelInfo.Definition.SourceAddress
@@ -246,19 +275,43 @@ func ReadShovel(d *schema.ResourceData, meta interface{}) error {
 	info["source_prefetch_count"] = shovelInfo.Definition.SourcePrefetchCount
 	info["source_protocol"] = shovelInfo.Definition.SourceProtocol
 	info["source_queue"] = shovelInfo.Definition.SourceQueue
-	if len(shovelInfo.Definition.SourceURI) > 0 {
-		info["source_uri"] = shovelInfo.Definition.SourceURI[0]
-	}
+	setShovelURIs(d, info, "source", shovelInfo.Definition.SourceURI)
 
 	d.Set("name", shovelInfo.Name)
 	d.Set("vhost", shovelInfo.Vhost)
+	// Not known to the server, so imported shovels and shovels created by
+	// earlier versions fall back to the default instead of showing a change.
+	d.Set("wait_for_running", d.Get("wait_for_running"))
 	d.Set("info", []map[string]interface{}{info})
 
 	return nil
 }
 
-func DeleteShovel(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateShovel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	shovelId := strings.Split(d.Id(), "@")
+
+	name := shovelId[0]
+	vhost := shovelId[1]
+
+	if d.HasChange("info") {
+		if err := declareShovel(rmqc, vhost, name, d.Get("info").([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if d.Get("wait_for_running").(bool) {
+			if err := waitForShovelRunning(ctx, meta.(*rabbitmqClient), vhost, name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return ReadShovel(ctx, d, meta)
+}
+
+func DeleteShovel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	shovelId := strings.Split(d.Id(), "@")
 
@@ -269,12 +322,35 @@ func DeleteShovel(d *schema.ResourceData, meta interface{}) error {
 
 	resp, err := rmqc.DeleteShovel(vhost, name)
 	log.Printf("[DEBUG] RabbitMQ: shovel deletion response: %#v", resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return diag.Errorf("Error deleting RabbitMQ shovel: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Declaring an existing shovel replaces its definition.
+func declareShovel(rmqc *rabbithole.Client, vhost string, name string, shovelInfo []interface{}) error {
+	shovelMap, ok := shovelInfo[0].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Unable to parse shovel info")
+	}
+
+	shovelDefinition := setShovelDefinition(shovelMap).(rabbithole.ShovelDefinition)
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare shovel %s in vhost %s", name, vhost)
+	resp, err := rmqc.DeclareShovel(vhost, name, shovelDefinition)
+	log.Printf("[DEBUG] RabbitMQ: shovel declartion response: %#v", resp)
 	if err != nil {
 		return err
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ shovel: %s", resp.Status)
+		return fmt.Errorf("Error declaring RabbitMQ shovel: %s", resp.Status)
 	}
 
 	return nil
@@ -335,10 +411,17 @@ func setShovelDefinition(shovelMap map[string]interface{}) interface{} {
 		shovelDefinition.DestinationQueue = v
 	}
 
-	if v, ok := shovelMap["destination_uri"].(string); ok {
+	if v, ok := shovelMap["destination_uri"].(string); ok && v != "" {
 		shovelDefinition.DestinationURI = []string{v}
 	}
 
+	if v, ok := shovelMap["destination_uris"].([]interface{}); ok && len(v) > 0 {
+		shovelDefinition.DestinationURI = make([]string, len(v))
+		for i, uri := range v {
+			shovelDefinition.DestinationURI[i], _ = uri.(string)
+		}
+	}
+
 	if v, ok := shovelMap["prefetch_count"].(int); ok {
 		shovelDefinition.PrefetchCount = v
 	}
@@ -373,9 +456,29 @@ func setShovelDefinition(shovelMap map[string]interface{}) interface{} {
 		shovelDefinition.SourceQueue = v
 	}
 
-	if v, ok := shovelMap["source_uri"].(string); ok {
+	if v, ok := shovelMap["source_uri"].(string); ok && v != "" {
 		shovelDefinition.SourceURI = []string{v}
 	}
 
+	if v, ok := shovelMap["source_uris"].([]interface{}); ok && len(v) > 0 {
+		shovelDefinition.SourceURI = make([]string, len(v))
+		for i, uri := range v {
+			shovelDefinition.SourceURI[i], _ = uri.(string)
+		}
+	}
+
 	return *shovelDefinition
 }
+
+/*
+Sets the URIs read back in the attribute used by the configuration, so
+that changes to any URI of the list show up as a difference.
+*/
+func setShovelURIs(d *schema.ResourceData, info map[string]interface{}, side string, uris []string) {
+	if _, ok := d.GetOk(fmt.Sprintf("info.0.%s_uris", side)); ok || len(uris) != 1 {
+		info[side+"_uris"] = uris
+		return
+	}
+
+	info[side+"_uri"] = uris[0]
+}
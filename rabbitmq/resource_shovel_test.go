@@ -2,6 +2,7 @@ package rabbitmq
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -28,6 +29,160 @@ func TestAccShovel(t *testing.T) {
 	})
 }
 
+func TestAccShovel_update(t *testing.T) {
+	var shovelInfo rabbithole.ShovelInfo
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccShovelCheckDestroy(&shovelInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccShovelConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccShovelCheck("rabbitmq_shovel.shovelTest", &shovelInfo),
+					testAccShovelCheckDefinition("rabbitmq_shovel.shovelTest", 1, 0),
+				),
+			},
+			{
+				Config: testAccShovelConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					testAccShovelCheck("rabbitmq_shovel.shovelTest", &shovelInfo),
+					testAccShovelCheckDefinition("rabbitmq_shovel.shovelTest", 5, 100),
+				),
+			},
+		},
+	})
+}
+
+func TestAccShovel_uris(t *testing.T) {
+	var shovelInfo rabbithole.ShovelInfo
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccShovelCheckDestroy(&shovelInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccShovelConfig_uris,
+				Check: resource.ComposeTestCheckFunc(
+					testAccShovelCheck("rabbitmq_shovel.shovelTest", &shovelInfo),
+					testAccShovelCheckURIs("rabbitmq_shovel.shovelTest", []string{"amqp:///test", "amqp://localhost/test"}),
+					resource.TestCheckResourceAttr("rabbitmq_shovel.shovelTest", "info.0.source_uris.#", "2"),
+				),
+			},
+			{
+				Config: testAccShovelConfig_uris,
+				Check: resource.ComposeTestCheckFunc(
+					testAccShovelCheck("rabbitmq_shovel.shovelTest", &shovelInfo),
+					testAccShovelChangeURIs("rabbitmq_shovel.shovelTest", []string{"amqp:///test"}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccShovel_waitForRunning(t *testing.T) {
+	var shovelInfo rabbithole.ShovelInfo
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccShovelCheckDestroy(&shovelInfo),
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(testAccShovelConfig_basic, "\tinfo {", "\twait_for_running = true\n\tinfo {", 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccShovelCheck("rabbitmq_shovel.shovelTest", &shovelInfo),
+					resource.TestCheckResourceAttr("rabbitmq_shovel.shovelTest", "wait_for_running", "true"),
+				),
+			},
+			{
+				Config:      strings.Replace(testAccShovelConfig_basic, "\tinfo {", "\twait_for_running = true\n\tinfo {", 1) + testAccShovelConfig_unreachable,
+				ExpectError: regexp.MustCompile("shovel shovelUnreachable@test"),
+			},
+		},
+	})
+}
+
+func testAccShovelCheckURIs(rn string, uris []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		shovelParts := strings.Split(rs.Primary.ID, "@")
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		shovel, err := rmqc.GetShovel(shovelParts[1], shovelParts[0])
+		if err != nil {
+			return fmt.Errorf("Error retrieving shovel: %s", err)
+		}
+
+		if fmt.Sprint(shovel.Definition.SourceURI) != fmt.Sprint(uris) {
+			return fmt.Errorf("Expected the source URIs %v, got %v", uris, shovel.Definition.SourceURI)
+		}
+
+		return nil
+	}
+}
+
+// Changes the source URIs outside of Terraform.
+func testAccShovelChangeURIs(rn string, uris []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		shovelParts := strings.Split(rs.Primary.ID, "@")
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		shovel, err := rmqc.GetShovel(shovelParts[1], shovelParts[0])
+		if err != nil {
+			return fmt.Errorf("Error retrieving shovel: %s", err)
+		}
+
+		shovel.Definition.SourceURI = uris
+
+		if _, err := rmqc.DeclareShovel(shovelParts[1], shovelParts[0], shovel.Definition); err != nil {
+			return fmt.Errorf("Error declaring shovel: %s", err)
+		}
+
+		return nil
+	}
+}
+
+// Checks the tuning of the shovel definition.
+func testAccShovelCheckDefinition(rn string, reconnectDelay int, prefetchCount int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		shovelParts := strings.Split(rs.Primary.ID, "@")
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		shovel, err := rmqc.GetShovel(shovelParts[1], shovelParts[0])
+		if err != nil {
+			return fmt.Errorf("Error retrieving shovel: %s", err)
+		}
+
+		if shovel.Definition.ReconnectDelay != reconnectDelay {
+			return fmt.Errorf("Expected a reconnect delay of %d, got %d", reconnectDelay, shovel.Definition.ReconnectDelay)
+		}
+
+		if shovel.Definition.SourcePrefetchCount != prefetchCount {
+			return fmt.Errorf("Expected a prefetch count of %d, got %d", prefetchCount, shovel.Definition.SourcePrefetchCount)
+		}
+
+		return nil
+	}
+}
+
 func testAccShovelCheck(rn string, shovelInfo *rabbithole.ShovelInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -39,7 +194,7 @@ func testAccShovelCheck(rn string, shovelInfo *rabbithole.ShovelInfo) resource.T
 			return fmt.Errorf("shovel id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		shovelParts := strings.Split(rs.Primary.ID, "@")
 
 		shovelInfos, err := rmqc.ListShovels()
@@ -60,7 +215,7 @@ func testAccShovelCheck(rn string, shovelInfo *rabbithole.ShovelInfo) resource.T
 
 func testAccShovelCheckDestroy(shovelInfo *rabbithole.ShovelInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 
 		shovelInfos, err := rmqc.ListShovels()
 		if err != nil {
@@ -122,3 +277,102 @@ resource "rabbitmq_shovel" "shovelTest" {
 		destination_queue = "${rabbitmq_queue.test.name}"
 	}
 }`
+
+const testAccShovelConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_exchange" "test" {
+    name = "test_exchange"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        type = "fanout"
+        durable = false
+        auto_delete = true
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+	name = "test_queue"
+	vhost = "${rabbitmq_exchange.test.vhost}"
+	settings {
+		durable = false
+		auto_delete = true
+	}
+}
+
+resource "rabbitmq_shovel" "shovelTest" {
+	name = "shovelTest"
+	vhost = "${rabbitmq_queue.test.vhost}"
+	info {
+		source_uri = "amqp:///test"
+		source_exchange = "${rabbitmq_exchange.test.name}"
+		source_exchange_key = "test"
+		source_prefetch_count = 100
+		destination_uri = "amqp:///test"
+		destination_queue = "${rabbitmq_queue.test.name}"
+		reconnect_delay = 5
+	}
+}`
+
+const testAccShovelConfig_uris = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+	name = "test_queue"
+	vhost = "${rabbitmq_permissions.guest.vhost}"
+	settings {
+		durable = false
+		auto_delete = true
+	}
+}
+
+resource "rabbitmq_shovel" "shovelTest" {
+	name = "shovelTest"
+	vhost = "${rabbitmq_queue.test.vhost}"
+	info {
+		source_uris = ["amqp:///test", "amqp://localhost/test"]
+		source_queue = "${rabbitmq_queue.test.name}"
+		destination_uri = "amqp:///test"
+		destination_queue = "${rabbitmq_queue.test.name}"
+	}
+}`
+
+const testAccShovelConfig_unreachable = `
+resource "rabbitmq_shovel" "shovelUnreachable" {
+	name = "shovelUnreachable"
+	vhost = "${rabbitmq_queue.test.vhost}"
+	wait_for_running = true
+	timeouts {
+		create = "30s"
+	}
+	info {
+		source_uri = "amqp://localhost:1/test"
+		source_queue = "${rabbitmq_queue.test.name}"
+		destination_uri = "amqp:///test"
+		destination_queue = "${rabbitmq_queue.test.name}"
+	}
+}`
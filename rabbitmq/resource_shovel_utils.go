@@ -0,0 +1,137 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+const (
+	shovelStateStarting   = "starting"
+	shovelStateRunning    = "running"
+	shovelStateTerminated = "terminated"
+)
+
+/*
+Runtime status of a shovel as reported by /api/shovels. Running
+shovels report their endpoints, while terminated ones report the
+reason they stopped.
+*/
+type shovelStatus struct {
+	Name string `json:"name"`
+
+	Vhost string `json:"vhost"`
+
+	Type string `json:"type"`
+
+	State string `json:"state"`
+
+	Node string `json:"node"`
+
+	Timestamp string `json:"timestamp"`
+
+	Reason string `json:"reason"`
+
+	SourceURI string `json:"src_uri"`
+
+	SourceProtocol string `json:"src_protocol"`
+
+	SourceQueue string `json:"src_queue"`
+
+	SourceExchange string `json:"src_exchange"`
+
+	DestinationURI string `json:"dest_uri"`
+
+	DestinationProtocol string `json:"dest_protocol"`
+
+	DestinationQueue string `json:"dest_queue"`
+
+	DestinationExchange string `json:"dest_exchange"`
+}
+
+// Returns the status of the shovel, or nil when it has not reported one yet.
+func getShovelStatus(ctx context.Context, rmqc *rabbitmqClient, vhost string, name string) (*shovelStatus, error) {
+
+	var statuses []shovelStatus
+
+	if err := rmqc.executeJSONRequest(ctx, http.MethodGet, "shovels/"+url.PathEscape(vhost), nil, &statuses); err != nil {
+
+		return nil, err
+	}
+
+	for _, status := range statuses {
+
+		if status.Name == name {
+
+			return &status, nil
+		}
+	}
+
+	return nil, nil
+}
+
+/*
+Waits until the shovel runs, failing with the reason reported by the
+shovel when it terminates or when it is still starting at the timeout.
+*/
+func waitForShovelRunning(ctx context.Context, rmqc *rabbitmqClient, vhost string, name string, timeout time.Duration) error {
+
+	var last *shovelStatus
+
+	stateConf := &retry.StateChangeConf{
+
+		Pending:    []string{"", shovelStateStarting},
+		Target:     []string{shovelStateRunning},
+		Timeout:    timeout,
+		MinTimeout: time.Second,
+
+		Refresh: func() (interface{}, string, error) {
+
+			status, err := getShovelStatus(ctx, rmqc, vhost, name)
+
+			if err != nil {
+
+				return nil, "", err
+			}
+
+			if status == nil {
+
+				// not reported yet
+				return struct{}{}, "", nil
+			}
+
+			last = status
+
+			log.Printf("[DEBUG] RabbitMQ: Shovel %s@%s is %s", name, vhost, status.State)
+
+			if status.State == shovelStateTerminated {
+
+				return status, status.State, fmt.Errorf("shovel %s@%s terminated: %s", name, vhost, status.Reason)
+			}
+
+			return status, status.State, nil
+		},
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+
+	var timeoutErr *retry.TimeoutError
+
+	if errors.As(err, &timeoutErr) && last != nil && last.Reason != "" {
+
+		return fmt.Errorf("shovel %s@%s is not running: %s (reason: %s)", name, vhost, err, last.Reason)
+	}
+
+	if err != nil {
+
+		return fmt.Errorf("shovel %s@%s is not running: %s", name, vhost, err)
+	}
+
+	return nil
+}
@@ -1,24 +1,32 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceTopicPermissions() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateTopicPermissions,
-		Update: UpdateTopicPermissions,
-		Read:   ReadTopicPermissions,
-		Delete: DeleteTopicPermissions,
+		CreateContext: CreateTopicPermissions,
+		UpdateContext: UpdateTopicPermissions,
+		ReadContext:   ReadTopicPermissions,
+		DeleteContext: DeleteTopicPermissions,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"user": {
@@ -61,8 +69,8 @@ func resourceTopicPermissions() *schema.Resource {
 }
 
 // CreateTopicPermissions for given exchanges
-func CreateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateTopicPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user := d.Get("user").(string)
 	vhost := d.Get("vhost").(string)
@@ -72,32 +80,32 @@ func CreateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 
 		permsMap, ok := exchange.(map[string]interface{})
 		if !ok {
-			return fmt.Errorf("Unable to parse permissions")
+			return diag.Errorf("Unable to parse permissions")
 		}
 
 		if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	id := fmt.Sprintf("%s@%s", user, vhost)
 	d.SetId(id)
 
-	return ReadTopicPermissions(d, meta)
+	return ReadTopicPermissions(ctx, d, meta)
 }
 
 // ReadTopicPermissions for the given ID
-func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadTopicPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, vhost, err := parseID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	userPerms, err := rmqc.GetTopicPermissionsIn(vhost, user)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Topic permission retrieved for %s: %#v", d.Id(), userPerms)
@@ -119,43 +127,42 @@ func ReadTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 }
 
 // UpdateTopicPermissions for given ID
-func UpdateTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateTopicPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, vhost, err := parseID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("permissions") {
-		err = DeleteTopicPermissions(d, meta)
-		if err != nil {
-			return err
+		if diags := DeleteTopicPermissions(ctx, d, meta); diags.HasError() {
+			return diags
 		}
 		_, newPerms := d.GetChange("permissions")
 		newPermsSet := newPerms.(*schema.Set)
 		for _, exchange := range newPermsSet.List() {
 			permsMap, ok := exchange.(map[string]interface{})
 			if !ok {
-				return fmt.Errorf("Unable to parse permissions")
+				return diag.Errorf("Unable to parse permissions")
 			}
 
 			if err := setTopicPermissionsIn(rmqc, vhost, user, permsMap); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
-	return ReadTopicPermissions(d, meta)
+	return ReadTopicPermissions(ctx, d, meta)
 }
 
 // DeleteTopicPermissions for given ID
-func DeleteTopicPermissions(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteTopicPermissions(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, vhost, err := parseID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete topic permission for %s", d.Id())
@@ -163,7 +170,7 @@ func DeleteTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.ClearTopicPermissionsIn(vhost, user)
 	log.Printf("[DEBUG] RabbitMQ: Topic permission delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -174,9 +181,9 @@ func DeleteTopicPermissions(d *schema.ResourceData, meta interface{}) error {
 	if resp.StatusCode >= 400 {
 		verErr := checkVersion(rmqc)
 		if verErr != nil {
-			return verErr
+			return diag.FromErr(verErr)
 		}
-		return fmt.Errorf("Error deleting RabbitMQ topic permission: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ topic permission: %s", resp.Status)
 	}
 
 	return nil
@@ -55,7 +55,7 @@ func testAccTopicPermissionsCheck(rn string, topicPermissionInfo *rabbithole.Top
 			return fmt.Errorf("permission id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		perms, err := rmqc.ListTopicPermissions()
 		if err != nil {
 			return fmt.Errorf("Error retrieving topic permissions: %s", err)
@@ -75,7 +75,7 @@ func testAccTopicPermissionsCheck(rn string, topicPermissionInfo *rabbithole.Top
 
 func testAccTopicPermissionsCheckDestroy(topicPermissionInfo *rabbithole.TopicPermissionInfo) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		perms, err := rmqc.ListTopicPermissions()
 		if err != nil {
 			return fmt.Errorf("Error retrieving topic permissions: %s", err)
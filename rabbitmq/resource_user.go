@@ -1,129 +1,251 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateUser,
-		Update: UpdateUser,
-		Read:   ReadUser,
-		Delete: DeleteUser,
+		CreateContext: CreateUser,
+		UpdateContext: UpdateUser,
+		ReadContext:   ReadUser,
+		DeleteContext: DeleteUser,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		CustomizeDiff: customizeUserDiff,
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceUserV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeUserStateV0,
 			},
+		},
+
+		Schema: resourceUserSchema(),
+	}
+}
+
+func resourceUserSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+
+		// Users without a password can only authenticate
+		// through certificates or another backend, e.g. OAuth 2.0.
+		"password": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			ConflictsWith: []string{"password_hash", "generate_password"},
+		},
+
+		"password_hash": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			ConflictsWith: []string{"password", "generate_password"},
+			ValidateFunc:  validation.StringIsBase64,
+		},
 
-			"password": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
+		// The password is generated by the provider and exported
+		// as generated_password.
+		"generate_password": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: []string{"password", "password_hash"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"length": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      32,
+						ValidateFunc: validation.IntAtLeast(8),
+					},
+
+					"lower": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+
+					"upper": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+
+					"numeric": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  true,
+					},
+
+					"special": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"override_special": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
 			},
+		},
+
+		// Any change of these values generates a new password.
+		"rotation_trigger": {
+			Type:         schema.TypeMap,
+			Optional:     true,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			RequiredWith: []string{"generate_password"},
+		},
+
+		"generated_password": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"hashing_algorithm": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice(userHashingAlgorithms, false),
+		},
 
-			"tags": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+		"tags": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validateUserTag,
 			},
 		},
 	}
 }
 
-func CreateUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func CreateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Get("name").(string)
 
-	userSettings := rabbithole.UserSettings{
-		Password: d.Get("password").(string),
-		Tags:     userTagsToString(d),
+	if err := generateUserPassword(d); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to create user %s", name)
 
-	resp, err := rmqc.PutUser(name, userSettings)
+	resp, err := putUser(rmqc, name, d)
 	log.Printf("[DEBUG] RabbitMQ: user creation response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error creating RabbitMQ user: %s", resp.Status)
+		return diag.Errorf("Error creating RabbitMQ user: %s", resp.Status)
 	}
 
 	d.SetId(name)
 
-	return ReadUser(d, meta)
+	return ReadUser(ctx, d, meta)
 }
 
-func ReadUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func ReadUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	user, err := rmqc.GetUser(d.Id())
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: User retrieved: %#v", user)
 
 	d.Set("name", user.Name)
+	d.Set("hashing_algorithm", string(user.HashingAlgorithm))
 
-	if len(user.Tags) > 0 {
-		var tagList []string
-		for _, v := range user.Tags {
-			if v != "" {
-				tagList = append(tagList, v)
-			}
-		}
-		if len(tagList) > 0 {
-			d.Set("tags", tagList)
+	// the hash is returned as declared, so that changes can be detected
+	if _, ok := d.GetOk("password_hash"); ok {
+		d.Set("password_hash", user.PasswordHash)
+	}
+
+	// a password changed outside of Terraform is set again on the next apply
+	if password, ok := d.GetOk("password"); ok && !passwordMatchesHash(password.(string), user.PasswordHash, user.HashingAlgorithm) {
+		log.Printf("[WARN] RabbitMQ: The password of user %s does not match the configured one", user.Name)
+		d.Set("password", "")
+	}
+
+	// clearing the generated password makes the next plan generate a new one
+	if password, ok := d.GetOk("generated_password"); ok && !passwordMatchesHash(password.(string), user.PasswordHash, user.HashingAlgorithm) {
+		log.Printf("[WARN] RabbitMQ: The password of user %s does not match the generated one", user.Name)
+		d.Set("generated_password", "")
+	}
+
+	tags := schema.NewSet(schema.HashString, nil)
+	for _, v := range user.Tags {
+		if v != "" {
+			tags.Add(v)
 		}
 	}
+	// an empty tag declares no tag at all and is kept as declared
+	if d.Get("tags").(*schema.Set).Contains("") {
+		tags.Add("")
+	}
+	d.Set("tags", tags)
 
 	return nil
 }
 
-func UpdateUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Id()
-	tags := userTagsToString(d)
-	password := d.Get("password").(string)
 
-	userSettings := rabbithole.UserSettings{
-		Password: password,
-		Tags:     tags,
+	if err := generateUserPassword(d); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to update user %s", name)
 
-	resp, err := rmqc.PutUser(name, userSettings)
+	resp, err := putUser(rmqc, name, d)
 	log.Printf("[DEBUG] RabbitMQ: User update response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error updating RabbitMQ user: %s", resp.Status)
+		return diag.Errorf("Error updating RabbitMQ user: %s", resp.Status)
 	}
 
-	return ReadUser(d, meta)
+	return ReadUser(ctx, d, meta)
 }
 
-func DeleteUser(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func DeleteUser(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	name := d.Id()
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete user %s", name)
@@ -131,7 +253,7 @@ func DeleteUser(d *schema.ResourceData, meta interface{}) error {
 	resp, err := rmqc.DeleteUser(name)
 	log.Printf("[DEBUG] RabbitMQ: User delete response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -140,7 +262,7 @@ func DeleteUser(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ user: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ user: %s", resp.Status)
 	}
 
 	return nil
@@ -148,11 +270,106 @@ func DeleteUser(d *schema.ResourceData, meta interface{}) error {
 
 func userTagsToString(d *schema.ResourceData) rabbithole.UserTags {
 	tagList := rabbithole.UserTags{}
-	for _, v := range d.Get("tags").([]interface{}) {
-		if tag, ok := v.(string); ok {
+	for _, v := range d.Get("tags").(*schema.Set).List() {
+		if tag, ok := v.(string); ok && tag != "" {
 			tagList = append(tagList, tag)
 		}
 	}
 
 	return tagList
 }
+
+func customizeUserDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if _, ok := d.GetOk("generate_password"); !ok {
+		if d.Get("generated_password").(string) != "" {
+			return d.SetNew("generated_password", "")
+		}
+		return nil
+	}
+
+	if !d.Get("generate_password.0.lower").(bool) &&
+		!d.Get("generate_password.0.upper").(bool) &&
+		!d.Get("generate_password.0.numeric").(bool) &&
+		!d.Get("generate_password.0.special").(bool) {
+		return fmt.Errorf("generate_password: at least one of lower, upper, numeric or special must be enabled")
+	}
+
+	if d.Get("generated_password").(string) == "" || d.HasChanges("generate_password", "rotation_trigger") {
+		return d.SetNewComputed("generated_password")
+	}
+
+	return nil
+}
+
+/*
+Generates a new password when password generation is enabled and the
+previous password was discarded by the plan.
+*/
+func generateUserPassword(d *schema.ResourceData) error {
+	if _, ok := d.GetOk("generate_password"); !ok {
+		d.Set("generated_password", "")
+		return nil
+	}
+
+	if d.Get("generated_password").(string) != "" {
+		return nil
+	}
+
+	settings := d.Get("generate_password").([]interface{})[0].(map[string]interface{})
+
+	password, err := randomPassword(
+		settings["length"].(int),
+		passwordCharset(
+			settings["lower"].(bool),
+			settings["upper"].(bool),
+			settings["numeric"].(bool),
+			settings["special"].(bool),
+			settings["override_special"].(string),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	d.Set("generated_password", password)
+
+	return nil
+}
+
+/*
+Declares the user with its password, its password hash or no password
+at all. When a hashing algorithm is set, the password is hashed locally
+so that the server uses that algorithm.
+*/
+func putUser(rmqc *rabbithole.Client, name string, d *schema.ResourceData) (*http.Response, error) {
+	userSettings := rabbithole.UserSettings{
+		Tags:             userTagsToString(d),
+		HashingAlgorithm: rabbithole.HashingAlgorithm(d.Get("hashing_algorithm").(string)),
+	}
+
+	password := d.Get("password").(string)
+	if _, ok := d.GetOk("generate_password"); ok {
+		password = d.Get("generated_password").(string)
+	}
+	passwordHash := d.Get("password_hash").(string)
+
+	switch {
+	case passwordHash != "":
+		userSettings.PasswordHash = passwordHash
+
+	case password != "" && userSettings.HashingAlgorithm != "":
+		hash, err := saltedPasswordHash(userSettings.HashingAlgorithm, nil, password)
+		if err != nil {
+			return nil, err
+		}
+		userSettings.PasswordHash = hash
+
+	case password != "":
+		userSettings.Password = password
+
+	default:
+		return rmqc.PutUserWithoutPassword(name, userSettings)
+	}
+
+	return rmqc.PutUser(name, userSettings)
+}
@@ -0,0 +1,45 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Version 0 declared the tags as a list, in which an empty tag declared no tag.
+func resourceUserV0() *schema.Resource {
+	userSchema := resourceUserSchema()
+
+	userSchema["tags"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	return &schema.Resource{
+		Schema: userSchema,
+	}
+}
+
+// Drops the duplicate tags that a set of tags cannot hold.
+func upgradeUserStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	tags := []interface{}{}
+	seen := make(map[string]bool)
+
+	if v, ok := rawState["tags"].([]interface{}); ok {
+		for _, tag := range v {
+			if tag, ok := tag.(string); ok && !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	rawState["tags"] = tags
+
+	return rawState, nil
+}
@@ -1,7 +1,10 @@
 package rabbitmq
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"reflect"
 	"testing"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
@@ -144,6 +147,255 @@ func TestAccUser_passwordChange(t *testing.T) {
 	})
 }
 
+func TestAccUser_passwordHash(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_passwordHash,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobar"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_user.test", "hashing_algorithm", "rabbit_password_hashing_sha512"),
+				),
+			},
+			{
+				Config: testAccUserConfig_hashingAlgorithm,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobarry"),
+					resource.TestCheckResourceAttr(
+						"rabbitmq_user.test", "hashing_algorithm", "rabbit_password_hashing_md5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_noPassword(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_noPassword,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_passwordDrift(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_passwordChange_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserChangePassword(&user, "changed"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccUserConfig_passwordChange_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserConnect("mctest", "foobar"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_generatePassword(t *testing.T) {
+	var user string
+	var password string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_generatePassword_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckGeneratedPassword("rabbitmq_user.test", &password, true),
+				),
+			},
+			{
+				Config: testAccUserConfig_generatePassword_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckGeneratedPassword("rabbitmq_user.test", &password, false),
+				),
+			},
+			{
+				Config: testAccUserConfig_generatePassword_2,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckGeneratedPassword("rabbitmq_user.test", &password, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_tagsDrift(t *testing.T) {
+	var user string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccUserCheckDestroy(user),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserConfig_tagsOrder_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 2),
+				),
+			},
+			{
+				// reordering tags is not a change
+				Config:   testAccUserConfig_tagsOrder_2,
+				PlanOnly: true,
+			},
+			{
+				Config: testAccUserConfig_tagsOrder_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserRemoveTags(&user),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccUserConfig_tagsOrder_1,
+				Check: resource.ComposeTestCheckFunc(
+					testAccUserCheck("rabbitmq_user.test", &user),
+					testAccUserCheckTagCount(&user, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateUserTag(t *testing.T) {
+	for tag, warnings := range map[string]int{
+		"administrator": 0,
+		"impersonator":  0,
+		"":              1,
+		"administrtor":  1,
+	} {
+		diags := validateUserTag(tag, nil)
+		if len(diags) != warnings {
+			t.Errorf("%q: expected %d warnings, got %d", tag, warnings, len(diags))
+		}
+		if diags.HasError() {
+			t.Errorf("%q: expected no error, got %v", tag, diags)
+		}
+	}
+}
+
+func TestUpgradeUserStateV0(t *testing.T) {
+	for name, test := range map[string]struct {
+		tags     interface{}
+		expected []interface{}
+	}{
+		"empty tag":      {tags: []interface{}{""}, expected: []interface{}{""}},
+		"tags":           {tags: []interface{}{"management", "administrator"}, expected: []interface{}{"management", "administrator"}},
+		"duplicate tags": {tags: []interface{}{"management", "", "management"}, expected: []interface{}{"management", ""}},
+		"no tags":        {tags: nil, expected: []interface{}{}},
+	} {
+		state, err := upgradeUserStateV0(context.Background(), map[string]interface{}{"name": "mctest", "tags": test.tags}, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if !reflect.DeepEqual(state["tags"], test.expected) {
+			t.Errorf("%s: expected tags %#v, got %#v", name, test.expected, state["tags"])
+		}
+		if state["name"] != "mctest" {
+			t.Errorf("%s: expected the other attributes to be kept, got %#v", name, state)
+		}
+	}
+}
+
+func TestRandomPassword(t *testing.T) {
+	password, err := randomPassword(40, passwordCharset(false, false, true, false, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(password) != 40 {
+		t.Errorf("expected a password of 40 characters, got %d", len(password))
+	}
+	for _, c := range password {
+		if c < '0' || c > '9' {
+			t.Errorf("unexpected character %q in password %s", c, password)
+		}
+	}
+
+	if _, err := randomPassword(40, ""); err == nil {
+		t.Error("expected an error for an empty set of characters")
+	}
+}
+
+func TestPasswordMatchesHash(t *testing.T) {
+	hash := rabbithole.Base64EncodedSaltedPasswordHashSHA256("foobar")
+
+	if !passwordMatchesHash("foobar", hash, "") {
+		t.Error("expected the password to match its hash")
+	}
+	if passwordMatchesHash("foobarry", hash, rabbithole.HashingAlgorithmSHA256) {
+		t.Error("expected another password not to match the hash")
+	}
+	if passwordMatchesHash("foobar", hash, rabbithole.HashingAlgorithmSHA512) {
+		t.Error("expected the password not to match a hash of another algorithm")
+	}
+	if passwordMatchesHash("foobar", "", "") {
+		t.Error("expected the password not to match an empty hash")
+	}
+}
+
+func TestSaltedPasswordHash(t *testing.T) {
+	for _, algorithm := range []rabbithole.HashingAlgorithm{
+		rabbithole.HashingAlgorithmSHA256,
+		rabbithole.HashingAlgorithmSHA512,
+	} {
+		expected := rabbithole.Base64EncodedSaltedPasswordHashSHA256("foobar")
+		if algorithm == rabbithole.HashingAlgorithmSHA512 {
+			expected = rabbithole.Base64EncodedSaltedPasswordHashSHA512("foobar")
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hash, err := saltedPasswordHash(algorithm, decoded[:userPasswordSaltLength], "foobar")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != expected {
+			t.Errorf("%s: expected %s, got %s", algorithm, expected, hash)
+		}
+	}
+
+	if _, err := saltedPasswordHash("rabbit_password_hashing_sha1", nil, "foobar"); err == nil {
+		t.Error("expected an error for an unsupported algorithm")
+	}
+}
+
 func testAccUserCheck(rn string, name *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -155,7 +407,7 @@ func testAccUserCheck(rn string, name *string) resource.TestCheckFunc {
 			return fmt.Errorf("user id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		users, err := rmqc.ListUsers()
 		if err != nil {
 			return fmt.Errorf("Error retrieving users: %s", err)
@@ -187,9 +439,65 @@ func testAccUserConnect(username, password string) resource.TestCheckFunc {
 	}
 }
 
+// Checks that the generated password can be used and whether it changed since the previous step.
+func testAccUserCheckGeneratedPassword(rn string, password *string, changed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		generated := rs.Primary.Attributes["generated_password"]
+		if generated == "" {
+			return fmt.Errorf("generated_password not set")
+		}
+		if changed == (generated == *password) {
+			return fmt.Errorf("expected the password to change: %t", changed)
+		}
+		*password = generated
+
+		return testAccUserConnect(rs.Primary.ID, generated)(s)
+	}
+}
+
+func testAccUserRemoveTags(name *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		_, err := rmqc.PutUser(*name, rabbithole.UserSettings{
+			Password: "foobar",
+			Tags:     rabbithole.UserTags{},
+		})
+		if err != nil {
+			return fmt.Errorf("Error removing the tags of user: %s", err)
+		}
+
+		return nil
+	}
+}
+
+func testAccUserChangePassword(name *string, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+		user, err := rmqc.GetUser(*name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving user: %s", err)
+		}
+
+		_, err = rmqc.PutUser(*name, rabbithole.UserSettings{
+			Password: password,
+			Tags:     user.Tags,
+		})
+		if err != nil {
+			return fmt.Errorf("Error changing the password of user: %s", err)
+		}
+
+		return nil
+	}
+}
+
 func testAccUserCheckTagCount(name *string, tagCount int) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		user, err := rmqc.GetUser(*name)
 		if err != nil {
 			return fmt.Errorf("Error retrieving user: %s", err)
@@ -212,7 +520,7 @@ func testAccUserCheckTagCount(name *string, tagCount int) resource.TestCheckFunc
 
 func testAccUserCheckDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		users, err := rmqc.ListUsers()
 		if err != nil {
 			return fmt.Errorf("Error retrieving users: %s", err)
@@ -296,3 +604,69 @@ resource "rabbitmq_user" "test" {
     password = "foobarry"
     tags = ["administrator", "management"]
 }`
+
+const testAccUserConfig_passwordHash = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password_hash = "kI3GCvvrflE2GusKGMXII7wYG5ucrA/btA/l9hgp3gFp1+FV6slxcVlj5HSHiXa23ZybDDj5LUsTvI2GacKLz6NUPnw="
+    hashing_algorithm = "rabbit_password_hashing_sha512"
+    tags = ["administrator"]
+}`
+
+const testAccUserConfig_hashingAlgorithm = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobarry"
+    hashing_algorithm = "rabbit_password_hashing_md5"
+    tags = ["administrator"]
+}`
+
+const testAccUserConfig_noPassword = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    tags = ["management"]
+}`
+
+const testAccUserConfig_generatePassword_1 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    tags = ["management"]
+
+    generate_password {
+        length  = 40
+        special = true
+    }
+
+    rotation_trigger = {
+        rotated = "2024-01"
+    }
+}`
+
+const testAccUserConfig_generatePassword_2 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    tags = ["management"]
+
+    generate_password {
+        length  = 40
+        special = true
+    }
+
+    rotation_trigger = {
+        rotated = "2024-02"
+    }
+}`
+
+const testAccUserConfig_tagsOrder_1 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["administrator", "management"]
+}`
+
+const testAccUserConfig_tagsOrder_2 = `
+resource "rabbitmq_user" "test" {
+    name = "mctest"
+    password = "foobar"
+    tags = ["management", "administrator"]
+}`
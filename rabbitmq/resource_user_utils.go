@@ -0,0 +1,214 @@
+package rabbitmq
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+var userHashingAlgorithms = []string{
+
+	string(rabbithole.HashingAlgorithmSHA256),
+	string(rabbithole.HashingAlgorithmSHA512),
+	string(rabbithole.HashingAlgorithmMD5),
+}
+
+// Tags known by RabbitMQ and its management plugin.
+var userKnownTags = []string{
+
+	"administrator",
+	"monitoring",
+	"policymaker",
+	"management",
+	"impersonator",
+}
+
+// Custom tags are allowed, e.g. for authorization backends, but are reported
+// since they are often typos of known tags. An empty tag declares no tag.
+func validateUserTag(v interface{}, path cty.Path) diag.Diagnostics {
+
+	tag := v.(string)
+
+	if tag == "" {
+
+		return diag.Diagnostics{
+
+			{
+				Severity:      diag.Warning,
+				Summary:       "Empty user tag",
+				Detail:        "An empty tag declares no tag. Omit tags or set it to an empty list instead.",
+				AttributePath: path,
+			},
+		}
+	}
+
+	for _, known := range userKnownTags {
+
+		if tag == known {
+
+			return nil
+		}
+	}
+
+	return diag.Diagnostics{
+
+		{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Unknown user tag %q", tag),
+			Detail:        fmt.Sprintf("RabbitMQ only grants permissions for the tags %s. Other tags are stored as is.", strings.Join(userKnownTags, ", ")),
+			AttributePath: path,
+		},
+	}
+}
+
+// Length of the salt prepended to password hashes.
+const userPasswordSaltLength = 4
+
+/*
+Computes a password hash the way RabbitMQ does, as the base64 encoding
+of the salt followed by the hash of the salt and the password. A random
+salt is generated when none is given.
+See https://www.rabbitmq.com/passwords.html#computing-password-hash
+*/
+func saltedPasswordHash(algorithm rabbithole.HashingAlgorithm, salt []byte, password string) (string, error) {
+
+	if salt == nil {
+
+		salt = make([]byte, userPasswordSaltLength)
+
+		if _, err := rand.Read(salt); err != nil {
+
+			return "", err
+		}
+	}
+
+	salted := append(append([]byte{}, salt...), password...)
+
+	var hash []byte
+
+	switch algorithm {
+
+	case rabbithole.HashingAlgorithmSHA256:
+		sum := sha256.Sum256(salted)
+		hash = sum[:]
+
+	case rabbithole.HashingAlgorithmSHA512:
+		sum := sha512.Sum512(salted)
+		hash = sum[:]
+
+	case rabbithole.HashingAlgorithmMD5:
+		sum := md5.Sum(salted)
+		hash = sum[:]
+
+	default:
+		return "", fmt.Errorf("unsupported password hashing algorithm %q", algorithm)
+	}
+
+	return base64.StdEncoding.EncodeToString(append(append([]byte{}, salt...), hash...)), nil
+}
+
+/*
+Tells whether the password hash stored by the server is the hash of the
+given password, by hashing the password again with the same salt.
+*/
+func passwordMatchesHash(password string, passwordHash string, algorithm rabbithole.HashingAlgorithm) bool {
+
+	decoded, err := base64.StdEncoding.DecodeString(passwordHash)
+
+	if err != nil || len(decoded) <= userPasswordSaltLength {
+
+		return false
+	}
+
+	// the server hashes with SHA-256 unless configured otherwise
+	if algorithm == "" {
+
+		algorithm = rabbithole.HashingAlgorithmSHA256
+	}
+
+	hash, err := saltedPasswordHash(algorithm, decoded[:userPasswordSaltLength], password)
+
+	if err != nil {
+
+		return false
+	}
+
+	return hash == passwordHash
+}
+
+const (
+	passwordLowerCharset   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperCharset   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordNumericCharset = "0123456789"
+	passwordSpecialCharset = "!#$%&*()-_=+[]{}<>:?"
+)
+
+func passwordCharset(lower, upper, numeric, special bool, overrideSpecial string) string {
+
+	charset := ""
+
+	if lower {
+
+		charset += passwordLowerCharset
+	}
+
+	if upper {
+
+		charset += passwordUpperCharset
+	}
+
+	if numeric {
+
+		charset += passwordNumericCharset
+	}
+
+	if special {
+
+		if overrideSpecial != "" {
+
+			charset += overrideSpecial
+
+		} else {
+
+			charset += passwordSpecialCharset
+		}
+	}
+
+	return charset
+}
+
+// Generates a password of the given length from a cryptographically secure source.
+func randomPassword(length int, charset string) (string, error) {
+
+	if charset == "" {
+
+		return "", fmt.Errorf("cannot generate a password from an empty set of characters")
+	}
+
+	max := big.NewInt(int64(len(charset)))
+	password := make([]byte, length)
+
+	for i := range password {
+
+		n, err := rand.Int(rand.Reader, max)
+
+		if err != nil {
+
+			return "", err
+		}
+
+		password[i] = charset[n.Int64()]
+	}
+
+	return string(password), nil
+}
@@ -1,22 +1,34 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceVhost() *schema.Resource {
 	return &schema.Resource{
-		Create: CreateVhost,
-		Read:   ReadVhost,
-		Delete: DeleteVhost,
+		CreateContext: CreateVhost,
+		ReadContext:   ReadVhost,
+		UpdateContext: UpdateVhost,
+		DeleteContext: DeleteVhost,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -24,52 +36,125 @@ func resourceVhost() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			// Settings are updated in place, as recreating
+			// a vhost deletes everything declared in it.
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tags": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			// Servers older than 3.11 do not support it.
+			"default_queue_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"classic",
+					"quorum",
+					"stream",
+				}, false),
+			},
+
+			"tracing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
-func CreateVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+// rabbit-hole leaves out the default queue type of vhosts.
+type vhostSettings struct {
+	rabbithole.VhostSettings
+
+	DefaultQueueType string `json:"default_queue_type,omitempty"`
+}
+
+type vhostInfo struct {
+	rabbithole.VhostInfo
+
+	DefaultQueueType string `json:"default_queue_type"`
 
+	Metadata struct {
+		DefaultQueueType string `json:"default_queue_type"`
+	} `json:"metadata"`
+}
+
+func CreateVhost(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vhost := d.Get("name").(string)
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to create vhost %s", vhost)
 
-	resp, err := rmqc.PutVhost(vhost, rabbithole.VhostSettings{})
-	log.Printf("[DEBUG] RabbitMQ: vhost creation response: %#v", resp)
-	if err != nil {
-		return err
+	if err := putVhost(ctx, meta.(*rabbitmqClient), vhost, d); err != nil {
+		return diag.FromErr(err)
 	}
 
 	d.SetId(vhost)
 
-	return ReadVhost(d, meta)
+	return ReadVhost(ctx, d, meta)
 }
 
-func ReadVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
-
-	vhost, err := rmqc.GetVhost(d.Id())
+func ReadVhost(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var vhost vhostInfo
+	err := meta.(*rabbitmqClient).executeJSONRequest(ctx, http.MethodGet, "vhosts/"+url.PathEscape(d.Id()), nil, &vhost)
 	if err != nil {
-		return checkDeleted(d, err)
+		return diag.FromErr(checkDeleted(d, err))
 	}
 
 	log.Printf("[DEBUG] RabbitMQ: Vhost retrieved: %#v", vhost)
 
 	d.Set("name", vhost.Name)
+	d.Set("description", vhost.Description)
+	d.Set("tracing", vhost.Tracing)
+
+	// an empty list of tags is returned as an empty string
+	tags := make([]string, 0, len(vhost.Tags))
+	for _, tag := range vhost.Tags {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	d.Set("tags", tags)
+
+	defaultQueueType := vhost.DefaultQueueType
+	if defaultQueueType == "" {
+		defaultQueueType = vhost.Metadata.DefaultQueueType
+	}
+	if defaultQueueType == "undefined" {
+		defaultQueueType = ""
+	}
+	d.Set("default_queue_type", defaultQueueType)
 
 	return nil
 }
 
-func DeleteVhost(d *schema.ResourceData, meta interface{}) error {
-	rmqc := meta.(*rabbithole.Client)
+func UpdateVhost(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("description", "tags", "default_queue_type", "tracing") {
+		if err := putVhost(ctx, meta.(*rabbitmqClient), d.Id(), d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadVhost(ctx, d, meta)
+}
+
+func DeleteVhost(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
 
 	log.Printf("[DEBUG] RabbitMQ: Attempting to delete vhost %s", d.Id())
 
 	resp, err := rmqc.DeleteVhost(d.Id())
 	log.Printf("[DEBUG] RabbitMQ: vhost deletion response: %#v", resp)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resp.StatusCode == 404 {
@@ -78,7 +163,30 @@ func DeleteVhost(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("Error deleting RabbitMQ user: %s", resp.Status)
+		return diag.Errorf("Error deleting RabbitMQ user: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func putVhost(ctx context.Context, rmqc *rabbitmqClient, vhost string, d *schema.ResourceData) error {
+	settings := vhostSettings{
+		VhostSettings: rabbithole.VhostSettings{
+			Description: d.Get("description").(string),
+			Tracing:     d.Get("tracing").(bool),
+		},
+		DefaultQueueType: d.Get("default_queue_type").(string),
+	}
+
+	for _, tag := range d.Get("tags").(*schema.Set).List() {
+		settings.Tags = append(settings.Tags, tag.(string))
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to declare vhost %s: %#v", vhost, settings)
+
+	err := rmqc.executeJSONRequest(ctx, http.MethodPut, "vhosts/"+url.PathEscape(vhost), settings, nil)
+	if err != nil {
+		return fmt.Errorf("Error declaring RabbitMQ vhost: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,187 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVhostParameter() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: CreateVhostParameter,
+		UpdateContext: UpdateVhostParameter,
+		ReadContext:   ReadVhostParameter,
+		DeleteContext: DeleteVhostParameter,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Read:   schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vhost": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// e.g. "federation-upstream", "federation-upstream-set" or "shovel"
+			"component": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+			},
+		},
+	}
+}
+
+func CreateVhostParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name := d.Get("name").(string)
+	vhost := d.Get("vhost").(string)
+	component := d.Get("component").(string)
+
+	if err := putVhostParameter(rmqc, component, vhost, name, d.Get("value").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id := fmt.Sprintf("%s@%s@%s", name, vhost, component)
+	d.SetId(id)
+
+	return ReadVhostParameter(ctx, d, meta)
+}
+
+func ReadVhostParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name, vhost, component, err := parseVhostParameterId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	parameter, err := rmqc.GetRuntimeParameter(component, vhost, name)
+	if err != nil {
+		return diag.FromErr(checkDeleted(d, err))
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Vhost parameter retrieved for %s: %#v", d.Id(), parameter)
+
+	value, err := json.Marshal(parameter.Value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", parameter.Name)
+	d.Set("vhost", parameter.Vhost)
+	d.Set("component", parameter.Component)
+	d.Set("value", string(value))
+
+	return nil
+}
+
+func UpdateVhostParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name, vhost, component, err := parseVhostParameterId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("value") {
+		if err := putVhostParameter(rmqc, component, vhost, name, d.Get("value").(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return ReadVhostParameter(ctx, d, meta)
+}
+
+func DeleteVhostParameter(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rmqc := meta.(*rabbitmqClient).WithContext(ctx)
+
+	name, vhost, component, err := parseVhostParameterId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to delete vhost parameter for %s", d.Id())
+
+	resp, err := rmqc.DeleteRuntimeParameter(component, vhost, name)
+	log.Printf("[DEBUG] RabbitMQ: Vhost parameter delete response: %#v", resp)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if resp.StatusCode == 404 {
+		// the parameter was already deleted
+		return nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return diag.Errorf("Error deleting RabbitMQ vhost parameter: %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Parses the name, vhost and component from an id in the format name@vhost@component.
+func parseVhostParameterId(id string) (string, string, string, error) {
+	name, vhost, args, err := parseIdWithArgs(id)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if len(args) != 1 {
+		return "", "", "", fmt.Errorf("Unable to determine vhost parameter ID from %s, expected name@vhost@component", id)
+	}
+
+	return name, vhost, args[0], nil
+}
+
+func putVhostParameter(rmqc *rabbithole.Client, component string, vhost string, name string, rawValue string) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+		return fmt.Errorf("Unable to parse the value of vhost parameter %s: %w", name, err)
+	}
+
+	log.Printf("[DEBUG] RabbitMQ: Attempting to set %s parameter %s@%s: %#v", component, name, vhost, value)
+
+	resp, err := rmqc.PutRuntimeParameter(component, vhost, name, value)
+	log.Printf("[DEBUG] RabbitMQ: Vhost parameter set response: %#v", resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("Error setting RabbitMQ vhost parameter: %s", resp.Status)
+	}
+
+	return nil
+}
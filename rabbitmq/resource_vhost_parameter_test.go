@@ -0,0 +1,137 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVhostParameter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVhostParameterCheckDestroy("federation-upstream-set", "test", "upstreams"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVhostParameterConfig_basic,
+				Check: testAccVhostParameterCheck(
+					"rabbitmq_vhost_parameter.test", `[{"upstream": "a"}]`,
+				),
+			},
+			{
+				Config: testAccVhostParameterConfig_update,
+				Check: testAccVhostParameterCheck(
+					"rabbitmq_vhost_parameter.test", `[{"upstream": "a"}, {"upstream": "b"}]`,
+				),
+			},
+		},
+	})
+}
+
+func testAccVhostParameterCheck(rn string, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", rn)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("vhost parameter id not set")
+		}
+
+		name, vhost, component, err := parseVhostParameterId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		parameter, err := rmqc.GetRuntimeParameter(component, vhost, name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving vhost parameter: %s", err)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal([]byte(expected), &value); err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(parameter.Value, value) {
+			return fmt.Errorf("Vhost parameter %s is %#v, expected %#v", rs.Primary.ID, parameter.Value, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccVhostParameterCheckDestroy(component string, vhost string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		parameters, err := rmqc.ListRuntimeParametersFor(component)
+		if err != nil {
+			return fmt.Errorf("Error retrieving vhost parameters: %s", err)
+		}
+
+		for _, parameter := range parameters {
+			if parameter.Name == name && parameter.Vhost == vhost {
+				return fmt.Errorf("Vhost parameter %s@%s@%s still exists", name, vhost, component)
+			}
+		}
+
+		return nil
+	}
+}
+
+const testAccVhostParameterConfig_basic = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_vhost_parameter" "test" {
+    name = "upstreams"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    component = "federation-upstream-set"
+    value = jsonencode([
+        { upstream = "a" },
+    ])
+}`
+
+const testAccVhostParameterConfig_update = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_vhost_parameter" "test" {
+    name = "upstreams"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    component = "federation-upstream-set"
+    value = jsonencode([
+        { upstream = "a" },
+        { upstream = "b" },
+    ])
+}`
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -37,9 +35,53 @@ func TestAccVhost(t *testing.T) {
 	})
 }
 
+func TestAccVhost_settings(t *testing.T) {
+	var vhost string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccVhostCheckDestroy(vhost),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVhostConfig_settings,
+				Check: resource.ComposeTestCheckFunc(
+					testAccVhostCheck("rabbitmq_vhost.test", &vhost),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "description", "Orders"),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "default_queue_type", "quorum"),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "tracing", "true"),
+				),
+			},
+			{
+				Config: testAccVhostConfig_settingsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccVhostCheck("rabbitmq_vhost.test", &vhost),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "description", "Orders and invoices"),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "tags.#", "0"),
+					resource.TestCheckResourceAttr("rabbitmq_vhost.test", "tracing", "false"),
+					// the vhost is updated in place, so the queue it holds remains
+					testAccVhostCheckQueue("test", "orders"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVhostCheckQueue(vhost string, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
+
+		if _, err := rmqc.GetQueue(vhost, name); err != nil {
+			return fmt.Errorf("Error retrieving queue %s@%s: %s", name, vhost, err)
+		}
+
+		return nil
+	}
+}
+
 func forceDropVhost(vhost *string) func() {
 	return func() {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		resp, err := rmqc.DeleteVhost(*vhost)
 		if err != nil {
 			fmt.Printf("unable to delete vhost: %v", err)
@@ -64,7 +106,7 @@ func testAccVhostCheck(rn string, name *string) resource.TestCheckFunc {
 			return fmt.Errorf("vhost id not set")
 		}
 
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		vhosts, err := rmqc.ListVhosts()
 		if err != nil {
 			return fmt.Errorf("Error retrieving vhosts: %s", err)
@@ -83,7 +125,7 @@ func testAccVhostCheck(rn string, name *string) resource.TestCheckFunc {
 
 func testAccVhostCheckDestroy(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		rmqc := testAccProvider.Meta().(*rabbithole.Client)
+		rmqc := testAccProvider.Meta().(*rabbitmqClient)
 		vhosts, err := rmqc.ListVhosts()
 		if err != nil {
 			return fmt.Errorf("Error retrieving vhosts: %s", err)
@@ -103,3 +145,55 @@ const testAccVhostConfig_basic = `
 resource "rabbitmq_vhost" "test" {
     name = "test"
 }`
+
+const testAccVhostConfig_settings = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+    description = "Orders"
+    tags = ["orders", "production"]
+    default_queue_type = "quorum"
+    tracing = true
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "orders"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        durable = true
+    }
+}`
+
+const testAccVhostConfig_settingsUpdate = `
+resource "rabbitmq_vhost" "test" {
+    name = "test"
+    description = "Orders and invoices"
+    default_queue_type = "quorum"
+}
+
+resource "rabbitmq_permissions" "guest" {
+    user = "guest"
+    vhost = "${rabbitmq_vhost.test.name}"
+    permissions {
+        configure = ".*"
+        write = ".*"
+        read = ".*"
+    }
+}
+
+resource "rabbitmq_queue" "test" {
+    name = "orders"
+    vhost = "${rabbitmq_permissions.guest.vhost}"
+    settings {
+        durable = true
+    }
+}`
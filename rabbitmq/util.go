@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
 )
 
+// Time given to each resource operation unless configured otherwise in a timeouts block.
+const defaultTimeout = 5 * time.Minute
+
 func checkDeleted(d *schema.ResourceData, err error) error {
 	var errorResponse rabbithole.ErrorResponse
 	if errors.As(err, &errorResponse) {
@@ -75,3 +80,76 @@ func toString(arguments map[string]interface{}) (result string) {
 
 	return string(raw)
 }
+
+/*
+Formats the values of a policy definition as strings to fit a map
+attribute, joining the nodes of ha-params with commas.
+*/
+func flattenDefinition(definition map[string]interface{}) map[string]interface{} {
+
+	result := make(map[string]interface{})
+
+	for key, value := range definition {
+
+		switch v := value.(type) {
+
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+
+		case []interface{}:
+			value = joinStrings(v)
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+/*
+Formats the values of optional arguments or of an effective policy
+definition read by data sources as strings to fit a map attribute,
+including the booleans and objects that policies don't flatten.
+*/
+func flattenArguments(arguments map[string]interface{}) map[string]interface{} {
+
+	result := make(map[string]interface{})
+
+	for key, value := range arguments {
+
+		switch v := value.(type) {
+
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+
+		case bool:
+			value = strconv.FormatBool(v)
+
+		case map[string]interface{}:
+			value = toString(v)
+
+		case []interface{}:
+			value = joinStrings(v)
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// Joins the strings of a list with commas, skipping other values.
+func joinStrings(values []interface{}) string {
+
+	var result []string
+
+	for _, value := range values {
+
+		if v, ok := value.(string); ok {
+
+			result = append(result, v)
+		}
+	}
+
+	return strings.Join(result, ",")
+}
@@ -0,0 +1,46 @@
+package rabbitmq
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlattenDefinition(t *testing.T) {
+	definition := map[string]interface{}{
+		"max-length": float64(10000),
+		"ha-mode":    "nodes",
+		"ha-params":  []interface{}{"rabbit@a", "rabbit@b"},
+		"custom":     true,
+	}
+
+	expected := map[string]interface{}{
+		"max-length": "10000",
+		"ha-mode":    "nodes",
+		"ha-params":  "rabbit@a,rabbit@b",
+		"custom":     true,
+	}
+
+	if result := flattenDefinition(definition); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %#v, got %#v", expected, result)
+	}
+}
+
+func TestFlattenArguments(t *testing.T) {
+	arguments := map[string]interface{}{
+		"x-max-length":             float64(10000),
+		"x-queue-type":             "quorum",
+		"x-single-active-consumer": true,
+		"x-custom":                 map[string]interface{}{"a": "b"},
+	}
+
+	expected := map[string]interface{}{
+		"x-max-length":             "10000",
+		"x-queue-type":             "quorum",
+		"x-single-active-consumer": "true",
+		"x-custom":                 `{"a":"b"}`,
+	}
+
+	if result := flattenArguments(arguments); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %#v, got %#v", expected, result)
+	}
+}